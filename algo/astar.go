@@ -40,21 +40,60 @@ import (
 // need to track already-visited nodes, it can safely return all known neighbours
 // of a node.
 func AStar[T comparable, D cmp.Ordered](start T, h func(T) D, visit func(T, D) (iter.Seq2[T, D], error)) (map[T][]T, error) {
+	p, err := AStarPaths(start, h, visit)
+	return p.Prev, err
+}
+
+// AStarPaths is like AStar, but returns the predecessor map together with the
+// distances to each node, as a *Paths.
+func AStarPaths[T comparable, D cmp.Ordered](start T, h func(T) D, visit func(T, D) (iter.Seq2[T, D], error)) (*Paths[T, D], error) {
+	return astar([]T{start}, h, nil, visit)
+}
+
+// AStarGoal is like AStarPaths, but stops searching once a goal node (a node
+// for which isGoal returns true) is reached. To search for any of a set of
+// goal nodes, pass the set's Contains method as isGoal. The goal reached, if
+// any, and its distance are available from the Goal method of the result.
+//
+// visit is not called for the goal. However, before stopping, nodes that are
+// tied with the goal in the priority queue are visited, so that all the
+// shortest paths to the goal are recorded in the predecessor map.
+func AStarGoal[T comparable, D cmp.Ordered](start T, h func(T) D, isGoal func(T) bool, visit func(T, D) (iter.Seq2[T, D], error)) (*Paths[T, D], error) {
+	return astar([]T{start}, h, isGoal, visit)
+}
+
+// astar implements AStarPaths, AStarGoal, and DijkstraMulti. isGoal may be
+// nil.
+func astar[T comparable, D cmp.Ordered](starts []T, h func(T) D, isGoal func(T) bool, visit func(T, D) (iter.Seq2[T, D], error)) (*Paths[T, D], error) {
 	prev := make(map[T][]T)
 	done := make(map[T]bool)
 	var zero D
-	dist := map[T]D{start: zero}
-	pq := new(PriQueue[T, D])
-	pq.Push(start, zero)
+	dist := make(map[T]D)
+	p := &Paths[T, D]{Prev: prev, dist: dist, starts: MakeSet(starts...)}
+	pq := new(IndexedPriQueue[T, D])
+	for _, start := range starts {
+		if _, seen := dist[start]; !seen {
+			dist[start] = zero
+			pq.Push(start, h(start))
+		}
+	}
+	var goalPri D
 	for pq.Len() > 0 {
-		node, _ := pq.Pop()
-		if done[node] {
-			continue
+		if _, pri := pq.Peek(); p.reached && pri > goalPri {
+			// No remaining node can be on a shortest path to the goal.
+			break
 		}
+		node, pri := pq.Pop()
 		done[node] = true
+		if isGoal != nil && isGoal(node) {
+			if !p.reached {
+				p.goal, p.reached, goalPri = node, true, pri
+			}
+			continue
+		}
 		it, err := visit(node, dist[node])
 		if err != nil {
-			return prev, err
+			return p, err
 		}
 		if it == nil {
 			continue
@@ -73,10 +112,12 @@ func AStar[T comparable, D cmp.Ordered](start T, h func(T) D, visit func(T, D) (
 			// !seen || (seen && olddist > newdist)
 			prev[newnode] = []T{node}
 			dist[newnode] = newdist
-			pq.Push(newnode, newdist+h(newnode))
+			if !done[newnode] {
+				pq.DecreaseKey(newnode, newdist+h(newnode))
+			}
 		}
 	}
-	return prev, nil
+	return p, nil
 }
 
 // Dijkstra is an implementation of Dijkstra's algorithm for single-source
@@ -99,3 +140,25 @@ func Dijkstra[T comparable, D cmp.Ordered](start T, visit func(T, D) (iter.Seq2[
 	var zero D
 	return AStar(start, func(T) D { return zero }, visit)
 }
+
+// DijkstraPaths is like Dijkstra, but returns the predecessor map together
+// with the distances to each node, as a *Paths.
+func DijkstraPaths[T comparable, D cmp.Ordered](start T, visit func(T, D) (iter.Seq2[T, D], error)) (*Paths[T, D], error) {
+	var zero D
+	return AStarPaths(start, func(T) D { return zero }, visit)
+}
+
+// DijkstraGoal is like DijkstraPaths, but stops searching once a goal node
+// is reached (see AStarGoal).
+func DijkstraGoal[T comparable, D cmp.Ordered](start T, isGoal func(T) bool, visit func(T, D) (iter.Seq2[T, D], error)) (*Paths[T, D], error) {
+	var zero D
+	return AStarGoal(start, func(T) D { return zero }, isGoal, visit)
+}
+
+// DijkstraMulti is like DijkstraPaths, but starts from several nodes at once,
+// each at distance zero. Each distance in the result is the distance from the
+// nearest start node, and paths begin at one of the start nodes.
+func DijkstraMulti[T comparable, D cmp.Ordered](starts []T, visit func(T, D) (iter.Seq2[T, D], error)) (*Paths[T, D], error) {
+	var zero D
+	return astar(starts, func(T) D { return zero }, nil, visit)
+}
@@ -0,0 +1,122 @@
+/*
+   Copyright 2026 Josh Deprez
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+	   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package algo
+
+import (
+	"cmp"
+	"iter"
+	"math/rand/v2"
+	"testing"
+
+	gocmp "github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+// astarDuplicates is the previous implementation of AStarPaths, which pushes
+// duplicate entries onto a PriQueue rather than decreasing keys, and skips
+// entries for nodes already visited.
+func astarDuplicates[T comparable, D cmp.Ordered](start T, h func(T) D, visit func(T, D) (iter.Seq2[T, D], error)) (map[T][]T, error) {
+	prev := make(map[T][]T)
+	done := make(map[T]bool)
+	var zero D
+	dist := map[T]D{start: zero}
+	pq := new(PriQueue[T, D])
+	pq.Push(start, zero)
+	for pq.Len() > 0 {
+		node, _ := pq.Pop()
+		if done[node] {
+			continue
+		}
+		done[node] = true
+		it, err := visit(node, dist[node])
+		if err != nil {
+			return prev, err
+		}
+		for newnode, weight := range it {
+			newdist := dist[node] + weight
+			if olddist, seen := dist[newnode]; seen {
+				switch {
+				case olddist < newdist:
+					continue
+				case olddist == newdist:
+					prev[newnode] = append(prev[newnode], node)
+					continue
+				}
+			}
+			prev[newnode] = []T{node}
+			dist[newnode] = newdist
+			pq.Push(newnode, newdist+h(newnode))
+		}
+	}
+	return prev, nil
+}
+
+// denseGraph returns a visit function for a complete directed graph on n
+// nodes with random weights. Dense graphs find many shorter paths to nodes
+// already in the queue.
+func denseGraph(n int) func(int, int) (iter.Seq2[int, int], error) {
+	r := rand.New(rand.NewPCG(1, 2))
+	w := make([][]int, n)
+	for i := range w {
+		w[i] = make([]int, n)
+		for j := range w[i] {
+			w[i][j] = 1 + r.IntN(1000)
+		}
+	}
+	return func(u, _ int) (iter.Seq2[int, int], error) {
+		return func(yield func(int, int) bool) {
+			for v, d := range w[u] {
+				if !yield(v, d) {
+					return
+				}
+			}
+		}, nil
+	}
+}
+
+func TestAStarMatchesDuplicates(t *testing.T) {
+	visit := denseGraph(200)
+	h := func(int) int { return 0 }
+	p, err := AStarPaths(0, h, visit)
+	if err != nil {
+		t.Fatalf("AStarPaths() error = %v", err)
+	}
+	want, err := astarDuplicates(0, h, visit)
+	if err != nil {
+		t.Fatalf("astarDuplicates() error = %v", err)
+	}
+	if diff := gocmp.Diff(p.Prev, want, cmpopts.SortSlices(cmp.Less[int])); diff != "" {
+		t.Errorf("AStarPaths().Prev diff (-got +want):\n%s", diff)
+	}
+}
+
+func BenchmarkAStarDense(b *testing.B) {
+	visit := denseGraph(1000)
+	h := func(int) int { return 0 }
+	b.Run("indexed", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			AStar(0, h, visit)
+		}
+	})
+	b.Run("duplicates", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			astarDuplicates(0, h, visit)
+		}
+	})
+}
@@ -0,0 +1,222 @@
+/*
+   Copyright 2026 Josh Deprez
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+	   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package algo
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// BidirectionalFloodFill finds a shortest path from start to goal in an
+// unweighted directed graph, by flood-filling from both ends at once until
+// the two searches meet. This visits far fewer nodes than FloodFill in
+// graphs where the number of nodes within distance d grows quickly with d.
+//
+// forward should return the nodes reachable by one edge from the given node,
+// and reverse should return the nodes from which the given node is reachable
+// by one edge (for undirected graphs, these are the same function). As with
+// FloodFill, they can return all neighbours, and if either returns an error,
+// the search halts and returns it.
+//
+// It returns a shortest path from start to goal inclusive of both ends, or
+// nil if goal is not reachable from start.
+func BidirectionalFloodFill[T comparable](start, goal T, forward, reverse func(T) (iter.Seq[T], error)) ([]T, error) {
+	if start == goal {
+		return []T{start}, nil
+	}
+	fwd := &bfsSide[T]{parent: map[T]T{}, dist: map[T]int{start: 0}, frontier: []T{start}, next: forward}
+	bwd := &bfsSide[T]{parent: map[T]T{}, dist: map[T]int{goal: 0}, frontier: []T{goal}, next: reverse}
+
+	for len(fwd.frontier) > 0 && len(bwd.frontier) > 0 {
+		// Expand the smaller frontier by one whole level.
+		side, other := fwd, bwd
+		if len(bwd.frontier) < len(fwd.frontier) {
+			side, other = bwd, fwd
+		}
+		meet, found, err := side.expand(other)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			path := chain(fwd.parent, meet)
+			slices.Reverse(path)
+			return append(path, chain(bwd.parent, meet)[1:]...), nil
+		}
+	}
+	return nil, nil
+}
+
+// bfsSide is one direction of a BidirectionalFloodFill.
+type bfsSide[T comparable] struct {
+	parent   map[T]T
+	dist     map[T]int
+	frontier []T
+	next     func(T) (iter.Seq[T], error)
+}
+
+// expand visits the whole frontier, replacing it with the next level. It
+// returns the meeting node on the shortest path through both sides, if the
+// new level meets nodes seen by the other side.
+func (s *bfsSide[T]) expand(other *bfsSide[T]) (meet T, found bool, err error) {
+	best := 0
+	var next []T
+	for _, node := range s.frontier {
+		it, err := s.next(node)
+		if err != nil {
+			return meet, false, err
+		}
+		if it == nil {
+			continue
+		}
+		d := s.dist[node] + 1
+		for n := range it {
+			if _, seen := s.dist[n]; seen {
+				continue
+			}
+			s.dist[n] = d
+			s.parent[n] = node
+			next = append(next, n)
+			if od, ok := other.dist[n]; ok && (!found || d+od < best) {
+				meet, found, best = n, true, d+od
+			}
+		}
+	}
+	s.frontier = next
+	return meet, found, nil
+}
+
+// chain returns the path from n back to the node with no parent, following
+// parent links.
+func chain[T comparable](parent map[T]T, n T) []T {
+	path := []T{n}
+	for {
+		p, ok := parent[n]
+		if !ok {
+			return path
+		}
+		path = append(path, p)
+		n = p
+	}
+}
+
+// BidirectionalDijkstra finds a shortest path from start to goal in a
+// directed, non-negatively weighted graph, by running Dijkstra's algorithm
+// from both ends at once until the two searches meet. Like
+// BidirectionalFloodFill, this can visit far fewer nodes than Dijkstra.
+//
+// forward should return the neighbours of the given node along with the
+// weight of the edge to each, and reverse should return the nodes with an
+// edge to the given node along with the weight of that edge (for undirected
+// graphs, these are the same function). If either returns an error, the
+// search halts and returns it.
+//
+// It returns a shortest path from start to goal inclusive of both ends, and
+// its length. If goal is not reachable from start, the path is nil.
+func BidirectionalDijkstra[T comparable, D cmp.Ordered](start, goal T, forward, reverse func(T) (iter.Seq2[T, D], error)) ([]T, D, error) {
+	var zero D
+	if start == goal {
+		return []T{start}, zero, nil
+	}
+	fwd := newDijkstraSide(start, forward)
+	bwd := newDijkstraSide(goal, reverse)
+
+	// best is the length of the shortest path found so far, via the edge
+	// from meetF (seen forwards) to meetB (seen backwards).
+	var best D
+	var meetF, meetB T
+	found := false
+
+	for fwd.pq.Len() > 0 && bwd.pq.Len() > 0 {
+		// Keys are popped in nondecreasing order from each side, so no path
+		// shorter than fwd.last + bwd.last remains to be found.
+		if found && fwd.last+bwd.last >= best {
+			break
+		}
+		side, other := fwd, bwd
+		if bwd.pq.Len() < fwd.pq.Len() {
+			side, other = bwd, fwd
+		}
+		node, ok := side.pop()
+		if !ok {
+			continue
+		}
+		it, err := side.next(node)
+		if err != nil {
+			return nil, zero, err
+		}
+		if it == nil {
+			continue
+		}
+		for n, w := range it {
+			d := side.dist[node] + w
+			if od, seen := side.dist[n]; !seen || d < od {
+				side.dist[n] = d
+				side.parent[n] = node
+				side.pq.Push(n, d)
+			}
+			if od, ok := other.dist[n]; ok && (!found || d+od < best) {
+				best, found = d+od, true
+				if side == fwd {
+					meetF, meetB = node, n
+				} else {
+					meetF, meetB = n, node
+				}
+			}
+		}
+	}
+	if !found {
+		return nil, zero, nil
+	}
+	path := chain(fwd.parent, meetF)
+	slices.Reverse(path)
+	return append(path, chain(bwd.parent, meetB)...), best, nil
+}
+
+// dijkstraSide is one direction of a BidirectionalDijkstra.
+type dijkstraSide[T comparable, D cmp.Ordered] struct {
+	parent map[T]T
+	dist   map[T]D
+	done   Set[T]
+	pq     PriQueue[T, D]
+	last   D // the key most recently popped from pq
+	next   func(T) (iter.Seq2[T, D], error)
+}
+
+func newDijkstraSide[T comparable, D cmp.Ordered](start T, next func(T) (iter.Seq2[T, D], error)) *dijkstraSide[T, D] {
+	var zero D
+	s := &dijkstraSide[T, D]{
+		parent: make(map[T]T),
+		dist:   map[T]D{start: zero},
+		done:   make(Set[T]),
+		next:   next,
+	}
+	s.pq.Push(start, zero)
+	return s
+}
+
+// pop pops the next node from the queue, returning false if it was already
+// visited.
+func (s *dijkstraSide[T, D]) pop() (T, bool) {
+	node, d := s.pq.Pop()
+	s.last = d
+	if s.done.Contains(node) {
+		return node, false
+	}
+	s.done.Insert(node)
+	return node, true
+}
@@ -0,0 +1,202 @@
+/*
+   Copyright 2026 Josh Deprez
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+	   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package algo
+
+import (
+	"fmt"
+	"iter"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// randomGraph returns the forward and reverse adjacency of a random directed
+// graph with weights in [0, maxWeight].
+func randomGraph(r *rand.Rand, nodes, edges, maxWeight int) (fwd, rev map[int]map[int]int) {
+	fwd, rev = make(map[int]map[int]int), make(map[int]map[int]int)
+	for range edges {
+		u, v, w := r.IntN(nodes), r.IntN(nodes), r.IntN(maxWeight+1)
+		if fwd[u] == nil {
+			fwd[u] = make(map[int]int)
+		}
+		if rev[v] == nil {
+			rev[v] = make(map[int]int)
+		}
+		if old, ok := fwd[u][v]; ok {
+			w = min(w, old)
+		}
+		fwd[u][v], rev[v][u] = w, w
+	}
+	return fwd, rev
+}
+
+// pathLength checks that each step of path is an edge in g, and returns the
+// total weight.
+func pathLength(t *testing.T, g map[int]map[int]int, path []int) int {
+	t.Helper()
+	total := 0
+	for i := 1; i < len(path); i++ {
+		w, ok := g[path[i-1]][path[i]]
+		if !ok {
+			t.Fatalf("path %v has non-edge %d -> %d", path, path[i-1], path[i])
+		}
+		total += w
+	}
+	return total
+}
+
+func TestBidirectionalDijkstra(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for trial := range 50 {
+		fwd, rev := randomGraph(r, 100, 250, 9)
+		adj := func(g map[int]map[int]int) func(int) (iter.Seq2[int, int], error) {
+			return func(n int) (iter.Seq2[int, int], error) { return maps.All(g[n]), nil }
+		}
+		want, err := DijkstraPaths(0, func(n, _ int) (iter.Seq2[int, int], error) { return adj(fwd)(n) })
+		if err != nil {
+			t.Fatalf("DijkstraPaths() error = %v", err)
+		}
+		for goal := range 100 {
+			path, dist, err := BidirectionalDijkstra(0, goal, adj(fwd), adj(rev))
+			if err != nil {
+				t.Fatalf("BidirectionalDijkstra() error = %v", err)
+			}
+			wantDist, reachable := want.Dist(goal)
+			if !reachable {
+				if path != nil {
+					t.Errorf("trial %d: BidirectionalDijkstra(0, %d) = %v, want nil", trial, goal, path)
+				}
+				continue
+			}
+			if dist != wantDist {
+				t.Errorf("trial %d: BidirectionalDijkstra(0, %d) dist = %d, want %d", trial, goal, dist, wantDist)
+			}
+			if path[0] != 0 || path[len(path)-1] != goal {
+				t.Errorf("trial %d: BidirectionalDijkstra(0, %d) = %v, want path from 0 to %d", trial, goal, path, goal)
+			}
+			if got := pathLength(t, fwd, path); got != wantDist {
+				t.Errorf("trial %d: BidirectionalDijkstra(0, %d) path %v has length %d, want %d", trial, goal, path, got, wantDist)
+			}
+		}
+	}
+}
+
+func TestBidirectionalFloodFill(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	for trial := range 50 {
+		fwd, rev := randomGraph(r, 100, 200, 0)
+		adj := func(g map[int]map[int]int) func(int) (iter.Seq[int], error) {
+			return func(n int) (iter.Seq[int], error) { return maps.Keys(g[n]), nil }
+		}
+		want, err := FloodFillPaths(0, func(n, _ int) (iter.Seq[int], error) { return adj(fwd)(n) })
+		if err != nil {
+			t.Fatalf("FloodFillPaths() error = %v", err)
+		}
+		for goal := range 100 {
+			path, err := BidirectionalFloodFill(0, goal, adj(fwd), adj(rev))
+			if err != nil {
+				t.Fatalf("BidirectionalFloodFill() error = %v", err)
+			}
+			wantDist, reachable := want.Dist(goal)
+			if !reachable {
+				if path != nil {
+					t.Errorf("trial %d: BidirectionalFloodFill(0, %d) = %v, want nil", trial, goal, path)
+				}
+				continue
+			}
+			pathLength(t, fwd, path) // checks the edges
+			if path[0] != 0 || path[len(path)-1] != goal || len(path)-1 != wantDist {
+				t.Errorf("trial %d: BidirectionalFloodFill(0, %d) = %v, want path of length %d from 0 to %d", trial, goal, path, wantDist, goal)
+			}
+		}
+	}
+}
+
+func ExampleBidirectionalFloodFill() {
+	// A combination lock with four wheels of digits. Each move turns one
+	// wheel by one step in either direction.
+	turns := func(s string) (iter.Seq[string], error) {
+		return func(yield func(string) bool) {
+			for i := range len(s) {
+				for _, d := range []byte{1, 9} {
+					t := []byte(s)
+					t[i] = '0' + (t[i]-'0'+d)%10
+					if !yield(string(t)) {
+						return
+					}
+				}
+			}
+		}, nil
+	}
+	path, _ := BidirectionalFloodFill("0000", "1909", turns, turns)
+	fmt.Println(len(path)-1, path[0], path[len(path)-1])
+	// Output:
+	// 3 0000 1909
+}
+
+func TestMultiSource(t *testing.T) {
+	// A path graph 0 - 1 - ... - 9, with starts at both ends.
+	neighbours := func(n int) []int {
+		var next []int
+		if n > 0 {
+			next = append(next, n-1)
+		}
+		if n < 9 {
+			next = append(next, n+1)
+		}
+		return next
+	}
+	line := func(n, _ int) (iter.Seq[int], error) {
+		return slices.Values(neighbours(n)), nil
+	}
+	p, err := FloodFillMulti([]int{0, 9}, line)
+	if err != nil {
+		t.Fatalf("FloodFillMulti() error = %v", err)
+	}
+	for n, want := range []int{0, 1, 2, 3, 4, 4, 3, 2, 1, 0} {
+		if got, _ := p.Dist(n); got != want {
+			t.Errorf("FloodFillMulti: Dist(%d) = %d, want %d", n, got, want)
+		}
+	}
+	if got, want := p.Path(7), []int{9, 8, 7}; !slices.Equal(got, want) {
+		t.Errorf("FloodFillMulti: Path(7) = %v, want %v", got, want)
+	}
+
+	weighted := func(n, _ int) (iter.Seq2[int, int], error) {
+		return func(yield func(int, int) bool) {
+			for _, m := range neighbours(n) {
+				if !yield(m, 2) {
+					return
+				}
+			}
+		}, nil
+	}
+	q, err := DijkstraMulti([]int{0, 9}, weighted)
+	if err != nil {
+		t.Fatalf("DijkstraMulti() error = %v", err)
+	}
+	for n := range 10 {
+		want, _ := p.Dist(n)
+		if got, _ := q.Dist(n); got != 2*want {
+			t.Errorf("DijkstraMulti: Dist(%d) = %d, want %d", n, got, 2*want)
+		}
+	}
+	if got := len(q.OnShortestPath(2)); got != 3 {
+		t.Errorf("DijkstraMulti: len(OnShortestPath(2)) = %d, want 3", got)
+	}
+}
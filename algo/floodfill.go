@@ -25,15 +25,36 @@ import "iter"
 // node. A more specific implementation than this one is more appropriate in
 // some cases, e.g. flood-filling a 2D grid.
 func FloodFill[T comparable](start T, visit func(T, int) (iter.Seq[T], error)) (map[T][]T, error) {
+	p, err := FloodFillPaths(start, visit)
+	return p.Prev, err
+}
+
+// FloodFillPaths is like FloodFill, but returns the predecessor map together
+// with the distances to each node, as a *Paths.
+func FloodFillPaths[T comparable](start T, visit func(T, int) (iter.Seq[T], error)) (*Paths[T, int], error) {
+	return FloodFillMulti([]T{start}, visit)
+}
+
+// FloodFillMulti is like FloodFillPaths, but starts from several nodes at
+// once, each at distance zero. Each distance in the result is the distance
+// from the nearest start node, and paths begin at one of the start nodes.
+func FloodFillMulti[T comparable](starts []T, visit func(T, int) (iter.Seq[T], error)) (*Paths[T, int], error) {
 	prev := make(map[T][]T)
-	dist := map[T]int{start: 0}
-	q := []T{start}
+	dist := make(map[T]int)
+	p := &Paths[T, int]{Prev: prev, dist: dist, starts: MakeSet(starts...)}
+	var q []T
+	for _, start := range starts {
+		if _, seen := dist[start]; !seen {
+			dist[start] = 0
+			q = append(q, start)
+		}
+	}
 	var node T
 	for len(q) > 0 {
 		node, q = q[0], q[1:]
 		next, err := visit(node, dist[node])
 		if err != nil {
-			return prev, err
+			return p, err
 		}
 		if next == nil {
 			continue
@@ -55,5 +76,5 @@ func FloodFill[T comparable](start T, visit func(T, int) (iter.Seq[T], error)) (
 			q = append(q, newnode)
 		}
 	}
-	return prev, nil
+	return p, nil
 }
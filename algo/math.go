@@ -98,6 +98,7 @@ func Min[T cmp.Ordered](x ...T) T {
 
 // Sum sums any slice where the elements support the + operator.
 // If len(in) == 0, the zero value for E is returned.
+// Integer sums wrap on overflow; see SumOverflow and SumSat.
 func Sum[S ~[]E, E Addable](in S) E {
 	var accum E
 	for _, x := range in {
@@ -107,6 +108,7 @@ func Sum[S ~[]E, E Addable](in S) E {
 }
 
 // PartialSums returns the partial sums (out[i] = in[0] + in[1] + ... + in[i])
+// Integer sums wrap on overflow; see PartialSumsOverflow and PartialSumsSat.
 func PartialSums[S ~[]E, E Addable](in S) S {
 	out := make(S, len(in))
 	var accum E
@@ -131,6 +133,7 @@ func Differences[S ~[]E, E constraints.Integer](in S) S {
 
 // Prod computes the product of elements in any slice where the element type
 // is numeric. If len(in) == 0, 1 is returned.
+// Integer products wrap on overflow; see ProdOverflow and ProdSat.
 func Prod[S ~[]E, E Numeric](in S) E {
 	var accum E = 1
 	for _, x := range in {
@@ -0,0 +1,228 @@
+/*
+   Copyright 2026 Josh Deprez
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+	   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package algo
+
+import "golang.org/x/exp/constraints"
+
+// This file implements overflow-checked ("Overflow") and saturating ("Sat")
+// integer arithmetic. The Overflow functions return the wrapped result
+// together with ok == false if overflow occurred. The Sat functions clamp the
+// result to the range of the type instead of wrapping.
+
+// AddOverflow returns a + b, and reports whether the sum did not overflow.
+func AddOverflow[E constraints.Integer](a, b E) (E, bool) {
+	s := a + b
+	if b >= 0 {
+		return s, s >= a
+	}
+	return s, s < a
+}
+
+// SubOverflow returns a - b, and reports whether the difference did not
+// overflow.
+func SubOverflow[E constraints.Integer](a, b E) (E, bool) {
+	d := a - b
+	if b >= 0 {
+		return d, d <= a
+	}
+	return d, d > a
+}
+
+// MulOverflow returns a * b, and reports whether the product did not
+// overflow.
+func MulOverflow[E constraints.Integer](a, b E) (E, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	p := a * b
+	// p/b == a catches everything except MinInt * -1 (p/b overflows too).
+	// For unsigned E, ^E(0) is the max value and a < 0 is always false.
+	return p, p/b == a && !(b == ^E(0) && a < 0 && p < 0)
+}
+
+// AddSat returns a + b, clamped to the range of E.
+func AddSat[E constraints.Integer](a, b E) E {
+	s, ok := AddOverflow(a, b)
+	if ok {
+		return s
+	}
+	lo, hi := intLimits[E]()
+	if b > 0 {
+		return hi
+	}
+	return lo
+}
+
+// SubSat returns a - b, clamped to the range of E.
+func SubSat[E constraints.Integer](a, b E) E {
+	d, ok := SubOverflow(a, b)
+	if ok {
+		return d
+	}
+	lo, hi := intLimits[E]()
+	if b > 0 {
+		return lo
+	}
+	return hi
+}
+
+// MulSat returns a * b, clamped to the range of E.
+func MulSat[E constraints.Integer](a, b E) E {
+	p, ok := MulOverflow(a, b)
+	if ok {
+		return p
+	}
+	lo, hi := intLimits[E]()
+	if (a < 0) != (b < 0) {
+		return lo
+	}
+	return hi
+}
+
+// SumOverflow is like Sum, but reports whether the sum did not overflow.
+// The sum is computed left to right; it stops and returns false at the first
+// partial sum that overflows, even if later terms would bring it back into
+// range.
+func SumOverflow[S ~[]E, E constraints.Integer](in S) (E, bool) {
+	var accum E
+	for _, x := range in {
+		var ok bool
+		accum, ok = AddOverflow(accum, x)
+		if !ok {
+			return accum, false
+		}
+	}
+	return accum, true
+}
+
+// SumSat is like Sum, but each addition saturates instead of wrapping.
+func SumSat[S ~[]E, E constraints.Integer](in S) E {
+	var accum E
+	for _, x := range in {
+		accum = AddSat(accum, x)
+	}
+	return accum
+}
+
+// PartialSumsOverflow is like PartialSums, but reports whether all the
+// partial sums were computed without overflow. If a partial sum overflows,
+// it returns the partial sums before the one that overflowed, and false.
+func PartialSumsOverflow[S ~[]E, E constraints.Integer](in S) (S, bool) {
+	out := make(S, 0, len(in))
+	var accum E
+	for _, x := range in {
+		var ok bool
+		accum, ok = AddOverflow(accum, x)
+		if !ok {
+			return out, false
+		}
+		out = append(out, accum)
+	}
+	return out, true
+}
+
+// PartialSumsSat is like PartialSums, but each addition saturates instead of
+// wrapping.
+func PartialSumsSat[S ~[]E, E constraints.Integer](in S) S {
+	out := make(S, len(in))
+	var accum E
+	for i, x := range in {
+		accum = AddSat(accum, x)
+		out[i] = accum
+	}
+	return out
+}
+
+// ProdOverflow is like Prod, but reports whether the product did not
+// overflow. It stops and returns false at the first partial product that
+// overflows (unless a later element is zero, in which case the product is
+// zero and no overflow is reported).
+func ProdOverflow[S ~[]E, E constraints.Integer](in S) (E, bool) {
+	var accum E = 1
+	ok := true
+	for _, x := range in {
+		if x == 0 {
+			return 0, true
+		}
+		if !ok {
+			continue
+		}
+		accum, ok = MulOverflow(accum, x)
+	}
+	return accum, ok
+}
+
+// ProdSat is like Prod, but each multiplication saturates instead of
+// wrapping.
+func ProdSat[S ~[]E, E constraints.Integer](in S) E {
+	var accum E = 1
+	for _, x := range in {
+		accum = MulSat(accum, x)
+	}
+	return accum
+}
+
+// PowOverflow is like Pow, but op also reports whether the operation
+// succeeded (e.g. MulOverflow). PowOverflow stops at the first failed op and
+// returns false. The same requirements on op and pow as for Pow apply.
+//
+// For a saturating power, pass MulSat to Pow.
+func PowOverflow[T any](base T, pow uint, op func(T, T) (T, bool)) (T, bool) {
+	if pow < 1 {
+		panic("pow must be at least 1")
+	}
+	if pow == 1 {
+		return base, true
+	}
+	var accum T
+	ini := false
+	for {
+		if pow%2 == 1 {
+			if ini {
+				var ok bool
+				if accum, ok = op(accum, base); !ok {
+					return accum, false
+				}
+			} else {
+				accum, ini = base, true
+			}
+		}
+		pow /= 2
+		if pow < 1 {
+			return accum, true
+		}
+		var ok bool
+		if base, ok = op(base, base); !ok {
+			return base, false
+		}
+	}
+}
+
+// intLimits returns the minimum and maximum values of E.
+func intLimits[E constraints.Integer]() (lo, hi E) {
+	hi = ^E(0)
+	if hi > 0 {
+		// Unsigned.
+		return 0, hi
+	}
+	// Signed: grow hi one bit at a time until the next bit is the sign bit.
+	hi = 1
+	for hi<<1 > 0 {
+		hi = hi<<1 | 1
+	}
+	return ^hi, hi
+}
@@ -0,0 +1,134 @@
+/*
+   Copyright 2026 Josh Deprez
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+	   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package algo
+
+import (
+	"math"
+	"testing"
+)
+
+// TestOverflowInt8 exhaustively checks the checked and saturating operations
+// for int8 against the same operations computed with int.
+func TestOverflowInt8(t *testing.T) {
+	clamp := func(x int) int8 { return int8(max(math.MinInt8, min(math.MaxInt8, x))) }
+	for a := math.MinInt8; a <= math.MaxInt8; a++ {
+		for b := math.MinInt8; b <= math.MaxInt8; b++ {
+			x, y := int8(a), int8(b)
+			ops := []struct {
+				name string
+				of   func(int8, int8) (int8, bool)
+				sat  func(int8, int8) int8
+				want int
+			}{
+				{"Add", AddOverflow[int8], AddSat[int8], a + b},
+				{"Sub", SubOverflow[int8], SubSat[int8], a - b},
+				{"Mul", MulOverflow[int8], MulSat[int8], a * b},
+			}
+			for _, op := range ops {
+				got, ok := op.of(x, y)
+				wantOK := op.want >= math.MinInt8 && op.want <= math.MaxInt8
+				if got != int8(op.want) || ok != wantOK {
+					t.Errorf("%sOverflow(%d, %d) = (%d, %t), want (%d, %t)", op.name, x, y, got, ok, int8(op.want), wantOK)
+				}
+				if got, want := op.sat(x, y), clamp(op.want); got != want {
+					t.Errorf("%sSat(%d, %d) = %d, want %d", op.name, x, y, got, want)
+				}
+			}
+		}
+	}
+}
+
+// TestOverflowUint8 exhaustively checks the checked and saturating operations
+// for uint8 against the same operations computed with int.
+func TestOverflowUint8(t *testing.T) {
+	clamp := func(x int) uint8 { return uint8(max(0, min(math.MaxUint8, x))) }
+	for a := 0; a <= math.MaxUint8; a++ {
+		for b := 0; b <= math.MaxUint8; b++ {
+			x, y := uint8(a), uint8(b)
+			ops := []struct {
+				name string
+				of   func(uint8, uint8) (uint8, bool)
+				sat  func(uint8, uint8) uint8
+				want int
+			}{
+				{"Add", AddOverflow[uint8], AddSat[uint8], a + b},
+				{"Sub", SubOverflow[uint8], SubSat[uint8], a - b},
+				{"Mul", MulOverflow[uint8], MulSat[uint8], a * b},
+			}
+			for _, op := range ops {
+				got, ok := op.of(x, y)
+				wantOK := op.want >= 0 && op.want <= math.MaxUint8
+				if got != uint8(op.want) || ok != wantOK {
+					t.Errorf("%sOverflow(%d, %d) = (%d, %t), want (%d, %t)", op.name, x, y, got, ok, uint8(op.want), wantOK)
+				}
+				if got, want := op.sat(x, y), clamp(op.want); got != want {
+					t.Errorf("%sSat(%d, %d) = %d, want %d", op.name, x, y, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestProdOverflow(t *testing.T) {
+	tests := []struct {
+		in     []int32
+		want   int32
+		wantOK bool
+		sat    int32
+	}{
+		{in: nil, want: 1, wantOK: true, sat: 1},
+		{in: []int32{2, 3, 7}, want: 42, wantOK: true, sat: 42},
+		{in: []int32{1 << 16, 1 << 16}, want: 0, wantOK: false, sat: math.MaxInt32},
+		{in: []int32{-1 << 16, 1 << 16}, want: 0, wantOK: false, sat: math.MinInt32},
+		{in: []int32{1 << 16, 1 << 16, 0}, want: 0, wantOK: true, sat: 0},
+	}
+	for _, test := range tests {
+		got, ok := ProdOverflow(test.in)
+		if got != test.want || ok != test.wantOK {
+			t.Errorf("ProdOverflow(%v) = (%d, %t), want (%d, %t)", test.in, got, ok, test.want, test.wantOK)
+		}
+		if got := ProdSat(test.in); got != test.sat {
+			t.Errorf("ProdSat(%v) = %d, want %d", test.in, got, test.sat)
+		}
+	}
+}
+
+func TestSumOverflow(t *testing.T) {
+	in := []int8{100, 20, 7, 1, -50}
+	if got, ok := SumOverflow(in); ok {
+		t.Errorf("SumOverflow(%v) = (%d, %t), want overflow", in, got, ok)
+	}
+	if got, want := SumSat(in), int8(77); got != want {
+		t.Errorf("SumSat(%v) = %d, want %d", in, got, want)
+	}
+	ps, ok := PartialSumsOverflow(in)
+	if want := []int8{100, 120, 127}; ok || len(ps) != len(want) || ps[2] != want[2] {
+		t.Errorf("PartialSumsOverflow(%v) = (%v, %t), want (%v, false)", in, ps, ok, want)
+	}
+}
+
+func TestPowOverflow(t *testing.T) {
+	if got, ok := PowOverflow[int64](3, 39, MulOverflow[int64]); !ok || got != 4052555153018976267 {
+		t.Errorf("PowOverflow(3, 39) = (%d, %t), want (4052555153018976267, true)", got, ok)
+	}
+	if got, ok := PowOverflow[int64](3, 40, MulOverflow[int64]); ok {
+		t.Errorf("PowOverflow(3, 40) = (%d, %t), want overflow", got, ok)
+	}
+	if got, want := Pow[int64](3, 40, MulSat[int64]), int64(math.MaxInt64); got != want {
+		t.Errorf("Pow(3, 40, MulSat) = %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,138 @@
+/*
+   Copyright 2026 Josh Deprez
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+	   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package algo
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// Paths is the result of a shortest-path search such as AStarPaths,
+// DijkstraPaths, or FloodFillPaths. Like the predecessor maps returned by
+// AStar and friends, it is only complete for visited nodes.
+type Paths[T comparable, D cmp.Ordered] struct {
+	// Prev maps each node to the previous node(s) on the shortest path(s)
+	// to that node.
+	Prev map[T][]T
+
+	dist    map[T]D
+	starts  Set[T]
+	goal    T
+	reached bool
+}
+
+// Goal returns the goal node that stopped the search, and its distance (see
+// AStarGoal). ok is false if no goal was reached (or the search had no goal).
+func (p *Paths[T, D]) Goal() (goal T, dist D, ok bool) {
+	if !p.reached {
+		return goal, dist, false
+	}
+	return p.goal, p.dist[p.goal], true
+}
+
+// Dist returns the length of the shortest path to the node, and whether the
+// node was reached at all.
+func (p *Paths[T, D]) Dist(node T) (D, bool) {
+	d, ok := p.dist[node]
+	return d, ok
+}
+
+// Path returns one shortest path from the start (or, for a multi-source
+// search, the nearest start) to the node, inclusive of both ends. It returns
+// nil if the node was not reached.
+func (p *Paths[T, D]) Path(to T) []T {
+	if _, ok := p.dist[to]; !ok {
+		return nil
+	}
+	path := []T{to}
+	for n := to; !p.starts.Contains(n) && len(p.Prev[n]) > 0; {
+		// The first predecessor recorded is always one that was visited
+		// earlier, so following first predecessors cannot loop.
+		n = p.Prev[n][0]
+		path = append(path, n)
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// AllPaths returns an iterator over all the shortest paths from the start (or
+// nearest starts) to the node, each inclusive of both ends. (When there are
+// edges of zero weight, only paths that don't visit any node twice are
+// included.) Each path is a new slice. If the node was not reached, there are
+// none.
+func (p *Paths[T, D]) AllPaths(to T) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		if _, ok := p.dist[to]; !ok {
+			return
+		}
+		// Walk backwards through predecessors, depth-first.
+		var rev []T
+		onPath := make(Set[T])
+		var walk func(n T) bool
+		walk = func(n T) bool {
+			rev = append(rev, n)
+			onPath.Insert(n)
+			defer func() {
+				rev = rev[:len(rev)-1]
+				delete(onPath, n)
+			}()
+
+			if p.starts.Contains(n) || len(p.Prev[n]) == 0 {
+				path := slices.Clone(rev)
+				slices.Reverse(path)
+				return yield(path)
+			}
+			for _, m := range p.Prev[n] {
+				if onPath.Contains(m) {
+					continue
+				}
+				if !walk(m) {
+					return false
+				}
+			}
+			return true
+		}
+		walk(to)
+	}
+}
+
+// OnShortestPath returns the set of nodes that lie on any shortest path from
+// the start (or nearest starts) to the node, including both ends. The set is
+// empty if the node was not reached.
+func (p *Paths[T, D]) OnShortestPath(to T) Set[T] {
+	on := make(Set[T])
+	if _, ok := p.dist[to]; !ok {
+		return on
+	}
+	q := []T{to}
+	on.Insert(to)
+	for len(q) > 0 {
+		n := q[0]
+		q = q[1:]
+		if p.starts.Contains(n) {
+			continue
+		}
+		for _, m := range p.Prev[n] {
+			if !on.Contains(m) {
+				on.Insert(m)
+				q = append(q, m)
+			}
+		}
+	}
+	return on
+}
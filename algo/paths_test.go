@@ -0,0 +1,205 @@
+/*
+   Copyright 2026 Josh Deprez
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+	   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package algo
+
+import (
+	"image"
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+// testGraph is a small weighted graph with several shortest paths from a to e.
+var testGraph = map[string]map[string]int{
+	"a": {"b": 1, "c": 1, "e": 5, "f": 10},
+	"b": {"d": 1},
+	"c": {"d": 1, "e": 3},
+	"d": {"e": 2},
+	"e": {"a": 1},
+	"z": {"a": 1},
+}
+
+func visitTestGraph(n string, _ int) (iter.Seq2[string, int], error) {
+	return maps.All(testGraph[n]), nil
+}
+
+func TestDijkstraPaths(t *testing.T) {
+	p, err := DijkstraPaths("a", visitTestGraph)
+	if err != nil {
+		t.Fatalf("DijkstraPaths() error = %v", err)
+	}
+
+	for node, want := range map[string]int{"a": 0, "b": 1, "c": 1, "d": 2, "e": 4, "f": 10} {
+		if got, ok := p.Dist(node); !ok || got != want {
+			t.Errorf("Dist(%q) = %d, %t, want %d, true", node, got, ok, want)
+		}
+	}
+	if _, ok := p.Dist("z"); ok {
+		t.Errorf("Dist(z) ok = true, want false")
+	}
+
+	path := p.Path("e")
+	if !slices.Contains([]string{"acde", "abde", "ace"}, strings.Join(path, "")) {
+		t.Errorf("Path(e) = %v, want a shortest path", path)
+	}
+	if got := p.Path("a"); !slices.Equal(got, []string{"a"}) {
+		t.Errorf("Path(a) = %v, want [a]", got)
+	}
+	if got := p.Path("z"); got != nil {
+		t.Errorf("Path(z) = %v, want nil", got)
+	}
+
+	var all []string
+	for path := range p.AllPaths("e") {
+		all = append(all, strings.Join(path, ""))
+	}
+	slices.Sort(all)
+	if diff := cmp.Diff(all, []string{"abde", "acde", "ace"}); diff != "" {
+		t.Errorf("AllPaths(e) diff (-got +want):\n%s", diff)
+	}
+
+	if got, want := p.OnShortestPath("e"), MakeSet("a", "b", "c", "d", "e"); !got.Equal(want) {
+		t.Errorf("OnShortestPath(e) = %v, want %v", got, want)
+	}
+	if got := p.OnShortestPath("z"); len(got) != 0 {
+		t.Errorf("OnShortestPath(z) = %v, want empty", got)
+	}
+
+	prev, err := Dijkstra("a", visitTestGraph)
+	if err != nil {
+		t.Fatalf("Dijkstra() error = %v", err)
+	}
+	if diff := cmp.Diff(prev, p.Prev, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+		t.Errorf("Dijkstra() and DijkstraPaths().Prev differ (-Dijkstra +DijkstraPaths):\n%s", diff)
+	}
+}
+
+func TestFloodFillPaths(t *testing.T) {
+	// Flood fill a 3x3 grid from the top-left corner.
+	bounds := image.Rect(0, 0, 3, 3)
+	p, err := FloodFillPaths(image.Pt(0, 0), func(n image.Point, _ int) (iter.Seq[image.Point], error) {
+		return func(yield func(image.Point) bool) {
+			for _, d := range []image.Point{{1, 0}, {0, 1}, {-1, 0}, {0, -1}} {
+				if m := n.Add(d); m.In(bounds) && !yield(m) {
+					return
+				}
+			}
+		}, nil
+	})
+	if err != nil {
+		t.Fatalf("FloodFillPaths() error = %v", err)
+	}
+
+	corner := image.Pt(2, 2)
+	if got, ok := p.Dist(corner); !ok || got != 4 {
+		t.Errorf("Dist(%v) = %d, %t, want 4, true", corner, got, ok)
+	}
+	path := p.Path(corner)
+	if len(path) != 5 || path[0] != image.Pt(0, 0) || path[4] != corner {
+		t.Errorf("Path(%v) = %v, want 5 steps from (0,0)", corner, path)
+	}
+	count := 0
+	for range p.AllPaths(corner) {
+		count++
+	}
+	if count != 6 {
+		t.Errorf("len(AllPaths(%v)) = %d, want 6", corner, count)
+	}
+	if got := len(p.OnShortestPath(corner)); got != 9 {
+		t.Errorf("len(OnShortestPath(%v)) = %d, want 9", corner, got)
+	}
+	if got := len(p.OnShortestPath(image.Pt(2, 0))); got != 3 {
+		t.Errorf("len(OnShortestPath((2,0))) = %d, want 3", got)
+	}
+}
+
+func TestDijkstraGoal(t *testing.T) {
+	var visited []string
+	visit := func(n string, d int) (iter.Seq2[string, int], error) {
+		visited = append(visited, n)
+		return visitTestGraph(n, d)
+	}
+	p, err := DijkstraGoal("a", MakeSet("e", "f").Contains, visit)
+	if err != nil {
+		t.Fatalf("DijkstraGoal() error = %v", err)
+	}
+	if goal, dist, ok := p.Goal(); goal != "e" || dist != 4 || !ok {
+		t.Errorf("Goal() = %q, %d, %t, want e, 4, true", goal, dist, ok)
+	}
+	slices.Sort(visited)
+	if diff := cmp.Diff(visited, []string{"a", "b", "c", "d"}); diff != "" {
+		t.Errorf("visited diff (-got +want):\n%s", diff)
+	}
+	var all []string
+	for path := range p.AllPaths("e") {
+		all = append(all, strings.Join(path, ""))
+	}
+	slices.Sort(all)
+	if diff := cmp.Diff(all, []string{"abde", "acde", "ace"}); diff != "" {
+		t.Errorf("AllPaths(e) diff (-got +want):\n%s", diff)
+	}
+
+	// With no reachable goal, the search is exhaustive.
+	p, err = DijkstraGoal("a", func(n string) bool { return n == "z" }, visitTestGraph)
+	if err != nil {
+		t.Fatalf("DijkstraGoal() error = %v", err)
+	}
+	if _, _, ok := p.Goal(); ok {
+		t.Errorf("Goal() ok = true, want false")
+	}
+	if _, ok := p.Dist("f"); !ok {
+		t.Errorf("Dist(f) ok = false, want true")
+	}
+}
+
+func TestAStarGoalTies(t *testing.T) {
+	// m is tied with the goal g, and lies on a second shortest path to it via
+	// a zero-weight edge.
+	graph := map[string]map[string]int{
+		"s": {"g": 2, "m": 2, "x": 3},
+		"m": {"g": 0},
+		"x": {"y": 1},
+	}
+	var visited []string
+	p, err := AStarGoal("s", func(string) int { return 0 }, func(n string) bool { return n == "g" },
+		func(n string, _ int) (iter.Seq2[string, int], error) {
+			visited = append(visited, n)
+			return maps.All(graph[n]), nil
+		})
+	if err != nil {
+		t.Fatalf("AStarGoal() error = %v", err)
+	}
+	if goal, dist, ok := p.Goal(); goal != "g" || dist != 2 || !ok {
+		t.Errorf("Goal() = %q, %d, %t, want g, 2, true", goal, dist, ok)
+	}
+	if slices.Contains(visited, "x") || slices.Contains(visited, "g") {
+		t.Errorf("visited = %v, want neither x nor g", visited)
+	}
+	var all []string
+	for path := range p.AllPaths("g") {
+		all = append(all, strings.Join(path, ""))
+	}
+	slices.Sort(all)
+	if diff := cmp.Diff(all, []string{"sg", "smg"}); diff != "" {
+		t.Errorf("AllPaths(g) diff (-got +want):\n%s", diff)
+	}
+}
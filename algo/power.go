@@ -24,7 +24,8 @@ package algo
 //
 // op is called O(log(pow) + bits.OnesCount(pow)) times.
 //
-// If you are working with big numbers, use math/big.
+// If you are working with big numbers, use math/big. To detect overflow, use
+// PowOverflow with MulOverflow, or to saturate instead, use Pow with MulSat.
 //
 // Note that op need *not* have an identity element, or even be generally
 // associative. *Power associativity* is the minimum condition needed to
@@ -40,6 +40,13 @@ func (pq *PriQueue[T, D]) Pop() (T, D) {
 	return hi.Item, hi.Weight
 }
 
+// Peek returns the item with the least priority value, and its priority,
+// without removing it. It panics if the queue is empty.
+func (pq *PriQueue[T, D]) Peek() (T, D) {
+	hi := (*pq)[0]
+	return hi.Item, hi.Weight
+}
+
 // Len returns the size of the queue.
 func (pq *PriQueue[T, D]) Len() int { return len(*pq) }
 
@@ -58,7 +65,261 @@ func (h *minHeap[T, D]) Pop() any {
 }
 
 // WeightedItem is an item together with a weight value.
-type WeightedItem[T any, D cmp.Ordered] struct {
+type WeightedItem[T, D any] struct {
 	Item   T
 	Weight D
 }
+
+// IndexedPriQueue implements a priority queue of distinct items of type T
+// each having a priority of type D. Unlike PriQueue, it keeps track of where
+// each item is in the queue, so the priority of an item already in the queue
+// can be changed (for example, decreased when a shorter path to a node is
+// found), and items can be removed. The zero value is an empty queue.
+type IndexedPriQueue[T comparable, D cmp.Ordered] struct {
+	heap  []WeightedItem[T, D]
+	index map[T]int // position of each item in heap
+}
+
+// Push adds an item to the queue with a priority. If the item is already in
+// the queue, its priority is changed (as for Update).
+func (pq *IndexedPriQueue[T, D]) Push(item T, priority D) {
+	pq.Update(item, priority)
+}
+
+// Update sets the priority of an item, adding it if it is not in the queue.
+func (pq *IndexedPriQueue[T, D]) Update(item T, priority D) {
+	if i, ok := pq.index[item]; ok {
+		old := pq.heap[i].Weight
+		pq.heap[i].Weight = priority
+		if priority < old {
+			pq.up(i)
+		} else {
+			pq.down(i)
+		}
+		return
+	}
+	if pq.index == nil {
+		pq.index = make(map[T]int)
+	}
+	pq.heap = append(pq.heap, WeightedItem[T, D]{Item: item, Weight: priority})
+	pq.index[item] = len(pq.heap) - 1
+	pq.up(len(pq.heap) - 1)
+}
+
+// DecreaseKey sets the priority of an item if it is not in the queue, or if
+// priority is less than its current priority. It reports whether the queue
+// changed.
+func (pq *IndexedPriQueue[T, D]) DecreaseKey(item T, priority D) bool {
+	if i, ok := pq.index[item]; ok && pq.heap[i].Weight <= priority {
+		return false
+	}
+	pq.Update(item, priority)
+	return true
+}
+
+// Pop removes the item with the least priority value, and returns both it and
+// its priority. It panics if the queue is empty.
+func (pq *IndexedPriQueue[T, D]) Pop() (T, D) {
+	hi := pq.heap[0]
+	pq.removeAt(0)
+	return hi.Item, hi.Weight
+}
+
+// Peek returns the item with the least priority value, and its priority,
+// without removing it. It panics if the queue is empty.
+func (pq *IndexedPriQueue[T, D]) Peek() (T, D) {
+	hi := pq.heap[0]
+	return hi.Item, hi.Weight
+}
+
+// Remove removes an item from the queue, returning its priority. It returns
+// false if the item was not in the queue.
+func (pq *IndexedPriQueue[T, D]) Remove(item T) (D, bool) {
+	i, ok := pq.index[item]
+	if !ok {
+		var zero D
+		return zero, false
+	}
+	priority := pq.heap[i].Weight
+	pq.removeAt(i)
+	return priority, true
+}
+
+// Contains reports whether the item is in the queue.
+func (pq *IndexedPriQueue[T, D]) Contains(item T) bool {
+	_, ok := pq.index[item]
+	return ok
+}
+
+// Priority returns the priority of an item, and whether it is in the queue.
+func (pq *IndexedPriQueue[T, D]) Priority(item T) (D, bool) {
+	i, ok := pq.index[item]
+	if !ok {
+		var zero D
+		return zero, false
+	}
+	return pq.heap[i].Weight, true
+}
+
+// Len returns the size of the queue.
+func (pq *IndexedPriQueue[T, D]) Len() int { return len(pq.heap) }
+
+// removeAt removes the item at position i in the heap.
+func (pq *IndexedPriQueue[T, D]) removeAt(i int) {
+	n1 := len(pq.heap) - 1
+	delete(pq.index, pq.heap[i].Item)
+	if i != n1 {
+		pq.heap[i] = pq.heap[n1]
+		pq.index[pq.heap[i].Item] = i
+	}
+	pq.heap[n1] = WeightedItem[T, D]{} // allow the item to be garbage collected
+	pq.heap = pq.heap[:n1]
+	if i != n1 {
+		pq.down(i)
+		pq.up(i)
+	}
+}
+
+// up moves the item at position i towards the root until the heap property
+// holds.
+func (pq *IndexedPriQueue[T, D]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if pq.heap[parent].Weight <= pq.heap[i].Weight {
+			return
+		}
+		pq.swap(i, parent)
+		i = parent
+	}
+}
+
+// down moves the item at position i away from the root until the heap
+// property holds.
+func (pq *IndexedPriQueue[T, D]) down(i int) {
+	n := len(pq.heap)
+	for {
+		least := i
+		if l := 2*i + 1; l < n && pq.heap[l].Weight < pq.heap[least].Weight {
+			least = l
+		}
+		if r := 2*i + 2; r < n && pq.heap[r].Weight < pq.heap[least].Weight {
+			least = r
+		}
+		if least == i {
+			return
+		}
+		pq.swap(i, least)
+		i = least
+	}
+}
+
+func (pq *IndexedPriQueue[T, D]) swap(i, j int) {
+	pq.heap[i], pq.heap[j] = pq.heap[j], pq.heap[i]
+	pq.index[pq.heap[i].Item] = i
+	pq.index[pq.heap[j].Item] = j
+}
+
+// MaxPriQueue implements a priority queue of items of type T each having a
+// priority of type D, where the item with the greatest priority value is
+// removed first. The zero value is an empty queue.
+type MaxPriQueue[T any, D cmp.Ordered] maxHeap[T, D]
+
+// Push adds an item to the queue with a priority.
+func (pq *MaxPriQueue[T, D]) Push(item T, priority D) {
+	heap.Push((*maxHeap[T, D])(pq), WeightedItem[T, D]{
+		Item:   item,
+		Weight: priority,
+	})
+}
+
+// Pop removes the item with the greatest priority value, and returns both it
+// and its priority. It panics if the queue is empty.
+func (pq *MaxPriQueue[T, D]) Pop() (T, D) {
+	hi := heap.Pop((*maxHeap[T, D])(pq)).(WeightedItem[T, D])
+	return hi.Item, hi.Weight
+}
+
+// Peek returns the item with the greatest priority value, and its priority,
+// without removing it. It panics if the queue is empty.
+func (pq *MaxPriQueue[T, D]) Peek() (T, D) {
+	hi := (*pq)[0]
+	return hi.Item, hi.Weight
+}
+
+// Len returns the size of the queue.
+func (pq *MaxPriQueue[T, D]) Len() int { return len(*pq) }
+
+// maxHeap provides the underlying implementation of heap.Interface.
+type maxHeap[T any, D cmp.Ordered] []WeightedItem[T, D]
+
+func (h maxHeap[T, D]) Len() int           { return len(h) }
+func (h maxHeap[T, D]) Less(i, j int) bool { return h[i].Weight > h[j].Weight }
+func (h maxHeap[T, D]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *maxHeap[T, D]) Push(x any)        { *h = append(*h, x.(WeightedItem[T, D])) }
+func (h *maxHeap[T, D]) Pop() any {
+	n1 := len(*h) - 1
+	i := (*h)[n1]
+	*h = (*h)[0:n1]
+	return i
+}
+
+// PriQueueFunc implements a priority queue of items of type T each having a
+// priority of type D, ordered by a comparison function. This allows
+// priorities that are not cmp.Ordered, such as tuples or structs. Use
+// NewPriQueueFunc to create one.
+type PriQueueFunc[T, D any] struct {
+	h funcHeap[T, D]
+}
+
+// NewPriQueueFunc returns an empty priority queue ordered by cmp, which
+// should return a negative number when a < b, a positive number when a > b,
+// and zero when a == b (as for slices.SortFunc). The item with the least
+// priority according to cmp is removed first. For a max-queue, reverse the
+// arguments to cmp.
+func NewPriQueueFunc[T, D any](cmp func(a, b D) int) *PriQueueFunc[T, D] {
+	return &PriQueueFunc[T, D]{h: funcHeap[T, D]{cmp: cmp}}
+}
+
+// Push adds an item to the queue with a priority.
+func (pq *PriQueueFunc[T, D]) Push(item T, priority D) {
+	heap.Push(&pq.h, WeightedItem[T, D]{
+		Item:   item,
+		Weight: priority,
+	})
+}
+
+// Pop removes the item with the least priority, and returns both it and its
+// priority. It panics if the queue is empty.
+func (pq *PriQueueFunc[T, D]) Pop() (T, D) {
+	hi := heap.Pop(&pq.h).(WeightedItem[T, D])
+	return hi.Item, hi.Weight
+}
+
+// Peek returns the item with the least priority, and its priority, without
+// removing it. It panics if the queue is empty.
+func (pq *PriQueueFunc[T, D]) Peek() (T, D) {
+	hi := pq.h.items[0]
+	return hi.Item, hi.Weight
+}
+
+// Len returns the size of the queue.
+func (pq *PriQueueFunc[T, D]) Len() int { return len(pq.h.items) }
+
+// funcHeap provides the underlying implementation of heap.Interface for
+// PriQueueFunc and TopK.
+type funcHeap[T, D any] struct {
+	items []WeightedItem[T, D]
+	cmp   func(a, b D) int
+}
+
+func (h *funcHeap[T, D]) Len() int           { return len(h.items) }
+func (h *funcHeap[T, D]) Less(i, j int) bool { return h.cmp(h.items[i].Weight, h.items[j].Weight) < 0 }
+func (h *funcHeap[T, D]) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *funcHeap[T, D]) Push(x any)         { h.items = append(h.items, x.(WeightedItem[T, D])) }
+func (h *funcHeap[T, D]) Pop() any {
+	n1 := len(h.items) - 1
+	i := h.items[n1]
+	h.items[n1] = WeightedItem[T, D]{} // allow the item to be garbage collected
+	h.items = h.items[0:n1]
+	return i
+}
@@ -0,0 +1,114 @@
+/*
+   Copyright 2026 Josh Deprez
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+	   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package algo
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestPriQueuePeek(t *testing.T) {
+	var pq PriQueue[string, int]
+	pq.Push("b", 2)
+	pq.Push("a", 1)
+	pq.Push("c", 3)
+	if item, pri := pq.Peek(); item != "a" || pri != 1 {
+		t.Errorf("Peek() = %q, %d, want a, 1", item, pri)
+	}
+	if got, want := pq.Len(), 3; got != want {
+		t.Errorf("Len() = %d, want %d", got, want)
+	}
+}
+
+func TestIndexedPriQueue(t *testing.T) {
+	// Apply random operations to both the queue and a map, and check they
+	// agree.
+	r := rand.New(rand.NewPCG(1, 2))
+	var pq IndexedPriQueue[int, int]
+	model := make(map[int]int)
+
+	minOf := func() (int, int) {
+		first := true
+		var item, pri int
+		for k, v := range model {
+			if first || v < pri || (v == pri && k < item) {
+				item, pri, first = k, v, false
+			}
+		}
+		return item, pri
+	}
+
+	for i := range 10000 {
+		item, pri := r.IntN(50), r.IntN(100)
+		switch r.IntN(5) {
+		case 0:
+			pq.Push(item, pri)
+			model[item] = pri
+		case 1:
+			old, ok := model[item]
+			changed := pq.DecreaseKey(item, pri)
+			if want := !ok || pri < old; changed != want {
+				t.Fatalf("op %d: DecreaseKey(%d, %d) = %t, want %t", i, item, pri, changed, want)
+			}
+			if changed {
+				model[item] = pri
+			}
+		case 2:
+			got, ok := pq.Remove(item)
+			want, wantOK := model[item]
+			if got != want || ok != wantOK {
+				t.Fatalf("op %d: Remove(%d) = %d, %t, want %d, %t", i, item, got, ok, want, wantOK)
+			}
+			delete(model, item)
+		case 3:
+			if len(model) == 0 {
+				continue
+			}
+			_, wantPri := minOf()
+			gotItem, gotPri := pq.Peek()
+			if gotPri != wantPri || model[gotItem] != wantPri {
+				t.Fatalf("op %d: Peek() = %d, %d, want priority %d", i, gotItem, gotPri, wantPri)
+			}
+			popItem, popPri := pq.Pop()
+			if popItem != gotItem || popPri != gotPri {
+				t.Fatalf("op %d: Pop() = %d, %d, want Peek() result %d, %d", i, popItem, popPri, gotItem, gotPri)
+			}
+			delete(model, popItem)
+		case 4:
+			want, wantOK := model[item]
+			if got, ok := pq.Priority(item); got != want || ok != wantOK {
+				t.Fatalf("op %d: Priority(%d) = %d, %t, want %d, %t", i, item, got, ok, want, wantOK)
+			}
+			if got := pq.Contains(item); got != wantOK {
+				t.Fatalf("op %d: Contains(%d) = %t, want %t", i, item, got, wantOK)
+			}
+		}
+		if pq.Len() != len(model) {
+			t.Fatalf("op %d: Len() = %d, want %d", i, pq.Len(), len(model))
+		}
+	}
+
+	// Draining the queue yields nondecreasing priorities.
+	last := -1
+	for pq.Len() > 0 {
+		_, pri := pq.Pop()
+		if pri < last {
+			t.Fatalf("Pop() priority %d after %d", pri, last)
+		}
+		last = pri
+	}
+}
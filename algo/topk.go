@@ -0,0 +1,90 @@
+/*
+   Copyright 2026 Josh Deprez
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+	   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package algo
+
+import (
+	"cmp"
+	"container/heap"
+	"slices"
+)
+
+// TopK collects the K items with the greatest priorities from a stream of
+// items, using O(K) memory. Use NewTopK or NewTopKFunc to create one.
+type TopK[T, D any] struct {
+	k int
+	h funcHeap[T, D] // min-heap of the kept items, so the root is the worst
+}
+
+// NewTopK returns an empty TopK that keeps the k items with the greatest
+// priority values. To keep the k least, use NewTopKFunc with a reversed
+// comparison.
+func NewTopK[T any, D cmp.Ordered](k int) *TopK[T, D] {
+	return NewTopKFunc[T](k, cmp.Compare[D])
+}
+
+// NewTopKFunc returns an empty TopK that keeps the k items with the greatest
+// priorities according to cmp (see NewPriQueueFunc).
+func NewTopKFunc[T, D any](k int, cmp func(a, b D) int) *TopK[T, D] {
+	if k < 0 {
+		panic("NewTopKFunc: k must be non-negative")
+	}
+	return &TopK[T, D]{
+		k: k,
+		h: funcHeap[T, D]{
+			items: make([]WeightedItem[T, D], 0, k),
+			cmp:   cmp,
+		},
+	}
+}
+
+// Push offers an item with a priority. It reports whether the item was kept:
+// it is kept if fewer than K items are held, or if its priority is greater
+// than that of the worst item held (which is then discarded). An item tied with
+// the worst item held is not kept.
+func (t *TopK[T, D]) Push(item T, priority D) bool {
+	wi := WeightedItem[T, D]{Item: item, Weight: priority}
+	if len(t.h.items) < t.k {
+		heap.Push(&t.h, wi)
+		return true
+	}
+	if t.k == 0 || t.h.cmp(priority, t.h.items[0].Weight) <= 0 {
+		return false
+	}
+	t.h.items[0] = wi
+	heap.Fix(&t.h, 0)
+	return true
+}
+
+// Min returns the worst item held, and its priority. Once K items are held,
+// this is the priority an item must beat to be kept. It panics if no items
+// are held.
+func (t *TopK[T, D]) Min() (T, D) {
+	wi := t.h.items[0]
+	return wi.Item, wi.Weight
+}
+
+// Len returns the number of items held, which is at most K.
+func (t *TopK[T, D]) Len() int { return len(t.h.items) }
+
+// Items returns the items held, ordered from greatest to least priority.
+func (t *TopK[T, D]) Items() []WeightedItem[T, D] {
+	items := slices.Clone(t.h.items)
+	slices.SortStableFunc(items, func(a, b WeightedItem[T, D]) int {
+		return t.h.cmp(b.Weight, a.Weight)
+	})
+	return items
+}
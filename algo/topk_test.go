@@ -0,0 +1,120 @@
+/*
+   Copyright 2026 Josh Deprez
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+	   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package algo
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	gocmp "github.com/google/go-cmp/cmp"
+)
+
+func TestMaxPriQueue(t *testing.T) {
+	var pq MaxPriQueue[string, int]
+	for i, s := range []string{"c", "a", "e", "b", "d"} {
+		pq.Push(s, []int{3, 1, 5, 2, 4}[i])
+	}
+	if item, pri := pq.Peek(); item != "e" || pri != 5 {
+		t.Errorf("Peek() = %q, %d, want e, 5", item, pri)
+	}
+	var got []string
+	for pq.Len() > 0 {
+		item, _ := pq.Pop()
+		got = append(got, item)
+	}
+	if diff := gocmp.Diff(got, []string{"e", "d", "c", "b", "a"}); diff != "" {
+		t.Errorf("Pop order diff (-got +want):\n%s", diff)
+	}
+}
+
+func TestPriQueueFunc(t *testing.T) {
+	type pri struct{ cost, steps int }
+	pq := NewPriQueueFunc[string](func(a, b pri) int {
+		return cmp.Or(cmp.Compare(a.cost, b.cost), cmp.Compare(b.steps, a.steps))
+	})
+	pq.Push("x", pri{2, 1})
+	pq.Push("y", pri{1, 1})
+	pq.Push("z", pri{2, 5})
+	pq.Push("w", pri{3, 0})
+	if item, p := pq.Peek(); item != "y" || p != (pri{1, 1}) {
+		t.Errorf("Peek() = %q, %v, want y, {1 1}", item, p)
+	}
+	var got []string
+	for pq.Len() > 0 {
+		item, _ := pq.Pop()
+		got = append(got, item)
+	}
+	if diff := gocmp.Diff(got, []string{"y", "z", "x", "w"}); diff != "" {
+		t.Errorf("Pop order diff (-got +want):\n%s", diff)
+	}
+}
+
+func TestTopK(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	var pris, all []int
+	top := NewTopK[int, int](10)
+	for i := range 1000 {
+		p := r.IntN(10000)
+		pris = append(pris, p)
+		top.Push(i, p)
+		if top.Len() != min(i+1, 10) {
+			t.Fatalf("after %d pushes: Len() = %d, want %d", i+1, top.Len(), min(i+1, 10))
+		}
+	}
+	all = slices.Clone(pris)
+	slices.Sort(all)
+	slices.Reverse(all)
+	var got []int
+	for _, wi := range top.Items() {
+		got = append(got, wi.Weight)
+		if wi.Weight != pris[wi.Item] {
+			t.Errorf("item %d has priority %d", wi.Item, wi.Weight)
+		}
+	}
+	if diff := gocmp.Diff(got, all[:10]); diff != "" {
+		t.Errorf("Items() priorities diff (-got +want):\n%s", diff)
+	}
+	if _, p := top.Min(); p != all[9] {
+		t.Errorf("Min() priority = %d, want %d", p, all[9])
+	}
+}
+
+func TestTopKFuncLeast(t *testing.T) {
+	top := NewTopKFunc[string](2, func(a, b int) int { return cmp.Compare(b, a) })
+	for i, s := range []string{"c", "a", "e", "b", "d"} {
+		top.Push(s, []int{3, 1, 5, 2, 4}[i])
+	}
+	want := []WeightedItem[string, int]{{"a", 1}, {"b", 2}}
+	if diff := gocmp.Diff(top.Items(), want); diff != "" {
+		t.Errorf("Items() diff (-got +want):\n%s", diff)
+	}
+	if top.Push("f", 2) {
+		t.Error("Push(f, 2) = true, want false (tied with worst)")
+	}
+}
+
+func TestTopKZero(t *testing.T) {
+	top := NewTopK[string, int](0)
+	if top.Push("a", 1) {
+		t.Error("Push(a, 1) = true, want false")
+	}
+	if top.Len() != 0 {
+		t.Errorf("Len() = %d, want 0", top.Len())
+	}
+}
@@ -0,0 +1,261 @@
+package exp
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Jitter selects how randomness is applied to each delay in a Backoff.
+type Jitter int
+
+const (
+	// FullJitter waits a random duration D ~ Uniform[0, d), where d is the
+	// current (capped) delay. This is the behaviour of Retry and RetryChan.
+	FullJitter Jitter = iota
+
+	// NoJitter waits exactly d.
+	NoJitter
+
+	// EqualJitter waits d/2 + D, where D ~ Uniform[0, d/2).
+	EqualJitter
+
+	// DecorrelatedJitter ignores Grow, and waits
+	// min(Max, D) where D ~ Uniform[Initial, 3*prev), and prev is the
+	// previous wait (initially Initial). Since each wait is based on the
+	// previous one, if Initial is zero then every wait is zero (as in the
+	// other modes).
+	DecorrelatedJitter
+)
+
+func (j Jitter) String() string {
+	switch j {
+	case FullJitter:
+		return "FullJitter"
+	case NoJitter:
+		return "NoJitter"
+	case EqualJitter:
+		return "EqualJitter"
+	case DecorrelatedJitter:
+		return "DecorrelatedJitter"
+	}
+	return fmt.Sprintf("Jitter(%d)", int(j))
+}
+
+// Backoff is a retry policy. The zero value makes unlimited attempts with no
+// waiting in between, so at least Initial should normally be set.
+//
+// Between attempts, the nth wait (starting from n = 0) is based on the delay
+// d = min(Max, Initial*Grow^n), with randomness applied according to Jitter.
+type Backoff struct {
+	// Count is the maximum number of attempts. If Count <= 0, the number of
+	// attempts is unlimited (but still subject to Budget and the context).
+	Count int
+
+	// Initial is the delay before the first retry.
+	Initial time.Duration
+
+	// Grow is the factor by which the delay increases after each retry.
+	// If Grow is zero, it is treated as 1 (the delay does not grow).
+	Grow float64
+
+	// Max caps the delay between attempts. If Max <= 0, there is no cap.
+	Max time.Duration
+
+	// Jitter selects how randomness is applied to each delay.
+	Jitter Jitter
+
+	// Budget is the overall time limit, measured from the first attempt.
+	// No attempt will be started after Budget has elapsed (and no time is
+	// wasted waiting for such an attempt). If Budget <= 0, there is no limit.
+	Budget time.Duration
+
+	// Clock is the source of time and timers. If nil, SystemClock is used.
+	Clock Clock
+
+	// Rand is the source of randomness for jitter. If nil, the global
+	// math/rand source is used. Since *rand.Rand is not safe for concurrent
+	// use, a Backoff with non-nil Rand should not be used by multiple
+	// goroutines at once.
+	Rand *rand.Rand
+}
+
+// Retry returns an iterator yielding the current time before each attempt,
+// according to the policy. See the Retry function for details on how time
+// spent in the loop body is treated.
+func (b Backoff) Retry(ctx context.Context) iter.Seq[time.Time] {
+	return b.retry(ctx, nil, nil)
+}
+
+// retry implements Retry. If adjust is not nil, it is called with each wait
+// (before checking the budget) and returns the wait to use instead. If
+// onWait is not nil, it is called with each wait just before waiting.
+func (b Backoff) retry(ctx context.Context, adjust func(time.Duration) time.Duration, onWait func(time.Duration)) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		if err := ctx.Err(); err != nil {
+			return
+		}
+
+		clock := clockOrSystem(b.Clock)
+		start := clock.Now()
+		if !yield(start) {
+			return
+		}
+		yieldDur := clock.Now().Sub(start)
+
+		bs := b.start(start)
+		for {
+			d, ok := bs.next()
+			if !ok {
+				return
+			}
+			d = max(d-yieldDur, 0)
+			if adjust != nil {
+				d = adjust(d)
+			}
+			if !bs.within(clock.Now().Add(d)) {
+				return
+			}
+			if onWait != nil {
+				onWait(d)
+			}
+			timer := clock.NewTimer(d)
+			select {
+			case t := <-timer.C():
+				if !yield(t) {
+					return
+				}
+				yieldDur = clock.Now().Sub(t)
+
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			}
+		}
+	}
+}
+
+// RetryChan returns a channel on which the current time is sent before each
+// attempt, according to the policy. See the RetryChan function for details on
+// how time spent receiving is treated.
+//
+// To ensure resources (internal goroutines) are cleaned up, cancel the context.
+// The channel is closed when the internal goroutine returns.
+func (b Backoff) RetryChan(ctx context.Context) <-chan time.Time {
+	ch := make(chan time.Time)
+	go func() {
+		defer close(ch)
+
+		clock := clockOrSystem(b.Clock)
+		start := clock.Now()
+		select {
+		case ch <- start:
+		case <-ctx.Done():
+			return
+		}
+
+		bs := b.start(start)
+		for {
+			d, ok := bs.next()
+			if !ok {
+				return
+			}
+			if !bs.within(clock.Now().Add(d)) {
+				return
+			}
+			timer := clock.NewTimer(d)
+			select {
+			case t := <-timer.C():
+				select {
+				case ch <- t:
+
+				case <-ctx.Done():
+					return
+				}
+
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// backoffState tracks progress through a Backoff policy.
+type backoffState struct {
+	Backoff
+	start    time.Time
+	attempts int           // attempts made so far
+	ceil     float64       // current uncapped delay
+	prev     time.Duration // previous wait, for DecorrelatedJitter
+}
+
+// start begins tracking a policy, with the first attempt made at t.
+func (b Backoff) start(t time.Time) *backoffState {
+	return &backoffState{
+		Backoff:  b,
+		start:    t,
+		attempts: 1,
+		ceil:     float64(b.Initial),
+		prev:     b.Initial,
+	}
+}
+
+// within reports whether an attempt at time t would be within the budget.
+func (s *backoffState) within(t time.Time) bool {
+	return s.Budget <= 0 || t.Sub(s.start) <= s.Budget
+}
+
+// next returns the wait before the next attempt, or false if the attempts
+// have been exhausted.
+func (s *backoffState) next() (time.Duration, bool) {
+	if s.Count > 0 && s.attempts >= s.Count {
+		return 0, false
+	}
+	s.attempts++
+
+	if s.Jitter == DecorrelatedJitter {
+		hi := time.Duration(math.MaxInt64)
+		if s.prev < hi/3 {
+			// Only multiply when it can't overflow.
+			hi = 3 * s.prev
+		}
+		d := s.Initial + s.randDuration(hi-s.Initial)
+		if s.Max > 0 {
+			d = min(d, s.Max)
+		}
+		s.prev = d
+		return d, true
+	}
+
+	d := time.Duration(s.ceil)
+	if s.Max > 0 && (d > s.Max || s.ceil > float64(s.Max)) {
+		// Comparing s.ceil too avoids trouble if the conversion overflowed.
+		d = s.Max
+	} else if s.Grow != 0 {
+		s.ceil *= s.Grow
+	}
+
+	switch s.Jitter {
+	case FullJitter:
+		d = s.randDuration(d)
+	case EqualJitter:
+		d = d/2 + s.randDuration(d-d/2)
+	}
+	return d, true
+}
+
+// randDuration returns a random duration D ~ Uniform[0, d), or 0 if d <= 0.
+func (s *backoffState) randDuration(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	if s.Rand != nil {
+		return time.Duration(s.Rand.Int63n(int64(d)))
+	}
+	return time.Duration(rand.Int63n(int64(d)))
+}
@@ -0,0 +1,158 @@
+package exp
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func ExampleBackoff_Retry() {
+	ctx, canc := context.WithCancel(context.Background())
+	defer canc()
+
+	policy := Backoff{
+		Count:   5,
+		Initial: time.Millisecond,
+		Grow:    2.0,
+		Max:     3 * time.Millisecond,
+		Jitter:  EqualJitter,
+	}
+
+	attempts := 0
+	for range policy.Retry(ctx) {
+		attempts++
+	}
+
+	fmt.Println(attempts)
+	// Output: 5
+}
+
+func TestBackoffSchedule(t *testing.T) {
+	b := Backoff{
+		Count:   7,
+		Initial: 10 * time.Millisecond,
+		Grow:    2,
+		Max:     100 * time.Millisecond,
+		Jitter:  NoJitter,
+	}
+	bs := b.start(time.Now())
+	var got []time.Duration
+	for {
+		d, ok := bs.next()
+		if !ok {
+			break
+		}
+		got = append(got, d)
+	}
+	want := []time.Duration{
+		10 * time.Millisecond,
+		20 * time.Millisecond,
+		40 * time.Millisecond,
+		80 * time.Millisecond,
+		100 * time.Millisecond,
+		100 * time.Millisecond,
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("backoff schedule diff (-got +want):\n%s", diff)
+	}
+}
+
+func TestBackoffJitterBounds(t *testing.T) {
+	const initial, ceiling = 10 * time.Millisecond, 200 * time.Millisecond
+	tests := []struct {
+		jitter Jitter
+		lo, hi func(d time.Duration) time.Duration
+	}{
+		{
+			jitter: FullJitter,
+			lo:     func(time.Duration) time.Duration { return 0 },
+			hi:     func(d time.Duration) time.Duration { return d },
+		},
+		{
+			jitter: EqualJitter,
+			lo:     func(d time.Duration) time.Duration { return d / 2 },
+			hi:     func(d time.Duration) time.Duration { return d },
+		},
+		{
+			jitter: DecorrelatedJitter,
+			lo:     func(time.Duration) time.Duration { return initial },
+			hi:     func(time.Duration) time.Duration { return ceiling },
+		},
+	}
+	for _, test := range tests {
+		b := Backoff{Initial: initial, Grow: 1.5, Max: ceiling, Jitter: test.jitter}
+		bs := b.start(time.Now())
+		d := float64(initial)
+		for range 100 {
+			ceil := time.Duration(min(d, float64(ceiling)))
+			got, ok := bs.next()
+			if !ok {
+				t.Fatalf("Backoff{Jitter: %v}: next() = _, false, want true (Count is unlimited)", test.jitter)
+			}
+			if lo, hi := test.lo(ceil), test.hi(ceil); got < lo || got > hi {
+				t.Errorf("Backoff{Jitter: %v}: next() = %v, want in [%v, %v]", test.jitter, got, lo, hi)
+			}
+			d *= 1.5
+		}
+	}
+}
+
+func TestBackoffDecorrelatedJitterEdges(t *testing.T) {
+	// With no Initial, every wait is zero.
+	bs := Backoff{Count: 10, Jitter: DecorrelatedJitter}.start(time.Now())
+	for {
+		d, ok := bs.next()
+		if !ok {
+			break
+		}
+		if d != 0 {
+			t.Errorf("Backoff{Initial: 0}: next() = %v, want 0", d)
+		}
+	}
+
+	// With a huge Initial and no Max, 3*prev must not overflow.
+	const initial = time.Duration(math.MaxInt64 / 2)
+	bs = Backoff{Count: 100, Initial: initial, Jitter: DecorrelatedJitter}.start(time.Now())
+	grew := false
+	for {
+		d, ok := bs.next()
+		if !ok {
+			break
+		}
+		if d < initial {
+			t.Errorf("Backoff{Initial: %v}: next() = %v, want at least %v", initial, d, initial)
+		}
+		grew = grew || d > initial
+	}
+	if !grew {
+		t.Errorf("Backoff{Initial: %v}: every wait was %v, want some longer", initial, initial)
+	}
+}
+
+func TestBackoffBudget(t *testing.T) {
+	b := Backoff{
+		Initial: 20 * time.Millisecond,
+		Jitter:  NoJitter,
+		Budget:  70 * time.Millisecond,
+	}
+	// Step through the schedule without waiting, as Retry would if each
+	// attempt took no time.
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	bs := b.start(now)
+	attempts := 1
+	for {
+		d, ok := bs.next()
+		if !ok || !bs.within(now.Add(d)) {
+			break
+		}
+		now = now.Add(d)
+		attempts++
+	}
+	if got, want := attempts, 4; got != want {
+		t.Errorf("attempts = %d, want %d", got, want)
+	}
+}
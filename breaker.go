@@ -0,0 +1,296 @@
+package exp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrBreakerOpen is returned (wrapped in a RetryAfterError hinting at the
+// remaining cool-down) by Breaker when a call is rejected. This lets Do wait
+// out the cool-down rather than hammering an open breaker.
+var ErrBreakerOpen = errors.New("circuit breaker is open")
+
+// BreakerState is the state of a Breaker.
+type BreakerState int
+
+const (
+	// BreakerClosed is the normal state: calls are allowed, and failures are
+	// counted.
+	BreakerClosed BreakerState = iota
+
+	// BreakerOpen rejects all calls until the cool-down period has elapsed.
+	BreakerOpen
+
+	// BreakerHalfOpen allows a limited number of trial calls. If they all
+	// succeed the breaker closes, and if any fails the breaker opens again.
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("BreakerState(%d)", int(s))
+}
+
+// Breaker is a circuit breaker. It is safe for concurrent use, but must not be
+// copied after first use. Configure it by setting the exported fields before
+// first use. If neither ConsecutiveFailures nor FailureRate is set, the
+// breaker never opens.
+//
+// A typical use wraps the breaker inside Do:
+//
+//	err := exp.Do(ctx, policy, func(ctx context.Context) error {
+//		return breaker.Do(ctx, callDependency)
+//	})
+type Breaker struct {
+	// ConsecutiveFailures, if > 0, opens the breaker after that many failures
+	// in a row.
+	ConsecutiveFailures int
+
+	// FailureRate, if > 0, opens the breaker when at least this fraction of
+	// the last Window results (while closed) were failures.
+	FailureRate float64
+
+	// Window is the number of recent results considered for FailureRate.
+	// The rate is not evaluated until Window results have been seen.
+	// If Window <= 0, 10 is used.
+	Window int
+
+	// CoolDown is how long the breaker stays open before allowing trial
+	// calls.
+	CoolDown time.Duration
+
+	// HalfOpenCalls is the number of trial calls allowed while half-open, all
+	// of which must succeed for the breaker to close. If HalfOpenCalls <= 0,
+	// 1 is used.
+	HalfOpenCalls int
+
+	// IsFailure classifies the result of a call. If nil, any non-nil error
+	// other than context.Canceled is a failure.
+	IsFailure func(error) bool
+
+	// OnStateChange, if not nil, is called after each change of state.
+	// It is called synchronously, but without any internal locks held.
+	OnStateChange func(from, to BreakerState)
+
+	// Clock is the source of time. If nil, SystemClock is used.
+	Clock Clock
+
+	mu          sync.Mutex
+	state       BreakerState
+	generation  uint64    // incremented on every state change
+	openedAt    time.Time // when the breaker last opened
+	consecutive int       // consecutive failures while closed
+	window      []bool    // ring buffer of recent results (true = failure)
+	windowPos   int       // next position to write in window
+	windowFails int       // number of failures in window
+	windowFull  bool      // whether window has wrapped
+	trials      int       // trial calls started while half-open
+	successes   int       // trial calls succeeded while half-open
+	changes     []breakerChange
+}
+
+type breakerChange struct{ from, to BreakerState }
+
+// State returns the current state of the breaker. An open breaker whose
+// cool-down has elapsed is reported as half-open.
+func (b *Breaker) State() BreakerState {
+	b.mu.Lock()
+	b.advance(clockOrSystem(b.Clock).Now())
+	s := b.state
+	b.unlock()
+	return s
+}
+
+// Allow asks the breaker for permission to make a call. If the call is
+// allowed, the caller must make it and then report its result by calling
+// done exactly once. If the call is not allowed, the error wraps
+// ErrBreakerOpen.
+func (b *Breaker) Allow() (done func(err error), err error) {
+	b.mu.Lock()
+	now := clockOrSystem(b.Clock).Now()
+	b.advance(now)
+	switch b.state {
+	case BreakerOpen:
+		wait := b.openedAt.Add(b.CoolDown).Sub(now)
+		b.unlock()
+		return nil, RetryAfter(ErrBreakerOpen, wait)
+
+	case BreakerHalfOpen:
+		if b.trials >= max(b.HalfOpenCalls, 1) {
+			b.unlock()
+			return nil, RetryAfter(ErrBreakerOpen, 0)
+		}
+		b.trials++
+	}
+	gen := b.generation
+	b.unlock()
+
+	var once sync.Once
+	return func(err error) {
+		once.Do(func() { b.record(gen, err) })
+	}, nil
+}
+
+// errPanicked is recorded as the result of a call that panicked.
+var errPanicked = errors.New("call panicked")
+
+// Do calls op if the breaker allows it, and records the result.
+// If the call is not allowed, it returns an error wrapping ErrBreakerOpen
+// without calling op. If op panics, the call is recorded as a failure and the
+// panic continues.
+func (b *Breaker) Do(ctx context.Context, op func(context.Context) error) error {
+	_, err := BreakerCall(ctx, b, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, op(ctx)
+	})
+	return err
+}
+
+// BreakerCall is like Breaker.Do, but for operations that return a value.
+func BreakerCall[T any](ctx context.Context, b *Breaker, op func(context.Context) (T, error)) (T, error) {
+	done, err := b.Allow()
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	// Record the result even if op panics, so that a half-open trial is not
+	// left outstanding forever.
+	returned := false
+	defer func() {
+		if !returned {
+			done(errPanicked)
+		}
+	}()
+	t, err := op(ctx)
+	returned = true
+	done(err)
+	return t, err
+}
+
+// record records the result of a call started in the given generation.
+func (b *Breaker) record(gen uint64, err error) {
+	b.mu.Lock()
+	defer b.unlock()
+
+	if gen != b.generation {
+		// The state changed while the call was in flight; the result is stale.
+		return
+	}
+	failed := b.isFailure(err)
+
+	switch b.state {
+	case BreakerClosed:
+		if failed {
+			b.consecutive++
+		} else {
+			b.consecutive = 0
+		}
+		b.push(failed)
+		if b.shouldTrip() {
+			b.setState(BreakerOpen, clockOrSystem(b.Clock).Now())
+		}
+
+	case BreakerHalfOpen:
+		if failed {
+			b.setState(BreakerOpen, clockOrSystem(b.Clock).Now())
+			return
+		}
+		b.successes++
+		if b.successes >= max(b.HalfOpenCalls, 1) {
+			b.setState(BreakerClosed, clockOrSystem(b.Clock).Now())
+		}
+	}
+}
+
+// shouldTrip reports whether the failure thresholds have been reached.
+// b.mu must be held.
+func (b *Breaker) shouldTrip() bool {
+	if b.ConsecutiveFailures > 0 && b.consecutive >= b.ConsecutiveFailures {
+		return true
+	}
+	if b.FailureRate > 0 && b.windowFull {
+		return float64(b.windowFails) >= b.FailureRate*float64(len(b.window))
+	}
+	return false
+}
+
+// push adds a result to the window. b.mu must be held.
+func (b *Breaker) push(failed bool) {
+	if b.window == nil {
+		n := b.Window
+		if n <= 0 {
+			n = 10
+		}
+		b.window = make([]bool, n)
+	}
+	if b.window[b.windowPos] {
+		b.windowFails--
+	}
+	b.window[b.windowPos] = failed
+	if failed {
+		b.windowFails++
+	}
+	b.windowPos++
+	if b.windowPos == len(b.window) {
+		b.windowPos, b.windowFull = 0, true
+	}
+}
+
+// advance moves an open breaker to half-open if the cool-down has elapsed.
+// b.mu must be held.
+func (b *Breaker) advance(now time.Time) {
+	if b.state == BreakerOpen && !now.Before(b.openedAt.Add(b.CoolDown)) {
+		b.setState(BreakerHalfOpen, now)
+	}
+}
+
+// setState changes the state and resets the counters. b.mu must be held.
+func (b *Breaker) setState(to BreakerState, now time.Time) {
+	from := b.state
+	if from == to {
+		return
+	}
+	b.state = to
+	b.generation++
+	b.consecutive = 0
+	b.trials, b.successes = 0, 0
+	if to == BreakerOpen {
+		b.openedAt = now
+	}
+	if to == BreakerClosed {
+		clear(b.window)
+		b.windowPos, b.windowFails, b.windowFull = 0, 0, false
+	}
+	if b.OnStateChange != nil {
+		b.changes = append(b.changes, breakerChange{from, to})
+	}
+}
+
+// unlock unlocks b.mu, then calls OnStateChange for any pending changes.
+func (b *Breaker) unlock() {
+	changes := b.changes
+	b.changes = nil
+	b.mu.Unlock()
+	for _, c := range changes {
+		b.OnStateChange(c.from, c.to)
+	}
+}
+
+func (b *Breaker) isFailure(err error) bool {
+	if err == errPanicked {
+		return true
+	}
+	if b.IsFailure != nil {
+		return b.IsFailure(err)
+	}
+	return err != nil && !errors.Is(err, context.Canceled)
+}
@@ -0,0 +1,244 @@
+package exp_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"drjosh.dev/exp"
+	"drjosh.dev/exp/exptest"
+	"github.com/google/go-cmp/cmp"
+)
+
+var errDependency = errors.New("dependency failed")
+
+func fail(context.Context) error    { return errDependency }
+func succeed(context.Context) error { return nil }
+
+func TestBreakerConsecutiveFailures(t *testing.T) {
+	ctx := context.Background()
+	clock := exptest.NewClock(epoch)
+	var changes []string
+	b := &exp.Breaker{
+		ConsecutiveFailures: 3,
+		CoolDown:            10 * time.Second,
+		Clock:               clock,
+		OnStateChange: func(from, to exp.BreakerState) {
+			changes = append(changes, from.String()+"->"+to.String())
+		},
+	}
+
+	b.Do(ctx, fail)
+	b.Do(ctx, fail)
+	b.Do(ctx, succeed) // resets the consecutive count
+	b.Do(ctx, fail)
+	b.Do(ctx, fail)
+	if got, want := b.State(), exp.BreakerClosed; got != want {
+		t.Fatalf("State() = %v, want %v", got, want)
+	}
+	b.Do(ctx, fail)
+	if got, want := b.State(), exp.BreakerOpen; got != want {
+		t.Fatalf("State() = %v, want %v", got, want)
+	}
+
+	called := false
+	err := b.Do(ctx, func(context.Context) error {
+		called = true
+		return nil
+	})
+	if called {
+		t.Errorf("open breaker called op")
+	}
+	if !errors.Is(err, exp.ErrBreakerOpen) {
+		t.Errorf("Do() error = %v, want %v", err, exp.ErrBreakerOpen)
+	}
+	var ra interface{ RetryAfter() time.Duration }
+	if !errors.As(err, &ra) || ra.RetryAfter() != 10*time.Second {
+		t.Errorf("Do() error = %v, want RetryAfter hint of %v", err, 10*time.Second)
+	}
+
+	clock.Advance(10 * time.Second)
+	if got, want := b.State(), exp.BreakerHalfOpen; got != want {
+		t.Fatalf("State() = %v, want %v", got, want)
+	}
+	b.Do(ctx, fail)
+	if got, want := b.State(), exp.BreakerOpen; got != want {
+		t.Fatalf("State() = %v, want %v", got, want)
+	}
+	clock.Advance(10 * time.Second)
+	if err := b.Do(ctx, succeed); err != nil {
+		t.Errorf("half-open Do() = %v, want nil", err)
+	}
+	if got, want := b.State(), exp.BreakerClosed; got != want {
+		t.Fatalf("State() = %v, want %v", got, want)
+	}
+
+	want := []string{
+		"closed->open",
+		"open->half-open",
+		"half-open->open",
+		"open->half-open",
+		"half-open->closed",
+	}
+	if diff := cmp.Diff(changes, want); diff != "" {
+		t.Errorf("state changes diff (-got +want):\n%s", diff)
+	}
+}
+
+func TestBreakerFailureRate(t *testing.T) {
+	ctx := context.Background()
+	b := &exp.Breaker{
+		FailureRate: 0.5,
+		Window:      4,
+		CoolDown:    time.Second,
+		Clock:       exptest.NewClock(epoch),
+	}
+	// 3 results isn't a full window, even though they're all failures.
+	b.Do(ctx, fail)
+	b.Do(ctx, fail)
+	b.Do(ctx, fail)
+	if got, want := b.State(), exp.BreakerClosed; got != want {
+		t.Fatalf("State() = %v, want %v", got, want)
+	}
+	b.Do(ctx, succeed)
+	if got, want := b.State(), exp.BreakerOpen; got != want {
+		t.Fatalf("State() = %v, want %v", got, want)
+	}
+}
+
+func TestBreakerHalfOpenLimit(t *testing.T) {
+	clock := exptest.NewClock(epoch)
+	b := &exp.Breaker{
+		ConsecutiveFailures: 1,
+		HalfOpenCalls:       2,
+		CoolDown:            time.Second,
+		Clock:               clock,
+	}
+	b.Do(context.Background(), fail)
+	clock.Advance(time.Second)
+
+	done1, err := b.Allow()
+	if err != nil {
+		t.Fatalf("Allow() #1 error = %v", err)
+	}
+	done2, err := b.Allow()
+	if err != nil {
+		t.Fatalf("Allow() #2 error = %v", err)
+	}
+	if _, err := b.Allow(); !errors.Is(err, exp.ErrBreakerOpen) {
+		t.Errorf("Allow() #3 error = %v, want %v", err, exp.ErrBreakerOpen)
+	}
+	done1(nil)
+	if got, want := b.State(), exp.BreakerHalfOpen; got != want {
+		t.Errorf("State() = %v, want %v", got, want)
+	}
+	done2(nil)
+	if got, want := b.State(), exp.BreakerClosed; got != want {
+		t.Errorf("State() = %v, want %v", got, want)
+	}
+}
+
+func TestBreakerHalfOpenPanic(t *testing.T) {
+	ctx := context.Background()
+	clock := exptest.NewClock(epoch)
+	b := &exp.Breaker{
+		ConsecutiveFailures: 1,
+		CoolDown:            time.Second,
+		Clock:               clock,
+		// Even if the classifier would ignore the panic, it is a failure.
+		IsFailure: func(err error) bool { return errors.Is(err, errDependency) },
+	}
+	b.Do(ctx, fail)
+	clock.Advance(time.Second)
+
+	func() {
+		defer func() {
+			if v := recover(); v != "boom" {
+				t.Errorf("recover() = %v, want boom", v)
+			}
+		}()
+		b.Do(ctx, func(context.Context) error { panic("boom") })
+	}()
+	if got, want := b.State(), exp.BreakerOpen; got != want {
+		t.Errorf("State() after panic = %v, want %v", got, want)
+	}
+
+	// The trial call was recorded, so the breaker recovers as usual.
+	clock.Advance(time.Second)
+	if err := b.Do(ctx, succeed); err != nil {
+		t.Errorf("Do(succeed) = %v, want nil", err)
+	}
+	if got, want := b.State(), exp.BreakerClosed; got != want {
+		t.Errorf("State() = %v, want %v", got, want)
+	}
+}
+
+func TestBreakerConcurrent(t *testing.T) {
+	const workers, calls = 8, 1000
+	clock := exptest.NewClock(epoch)
+	var (
+		mu      sync.Mutex
+		changes []string
+	)
+	b := &exp.Breaker{
+		ConsecutiveFailures: 10,
+		CoolDown:            time.Minute, // the fake clock never reaches it
+		Clock:               clock,
+		OnStateChange: func(from, to exp.BreakerState) {
+			mu.Lock()
+			defer mu.Unlock()
+			changes = append(changes, from.String()+"->"+to.String())
+		},
+	}
+
+	// run makes calls to op from several goroutines at once, and counts the
+	// calls that were allowed and rejected.
+	run := func(op func(context.Context) error) (allowed, rejected int64) {
+		var na, nr atomic.Int64
+		var wg sync.WaitGroup
+		for range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range calls {
+					err := b.Do(context.Background(), func(ctx context.Context) error {
+						na.Add(1)
+						return op(ctx)
+					})
+					if errors.Is(err, exp.ErrBreakerOpen) {
+						nr.Add(1)
+					}
+				}
+			}()
+		}
+		wg.Wait()
+		return na.Load(), nr.Load()
+	}
+
+	// Successes never trip the breaker.
+	if allowed, rejected := run(succeed); allowed != workers*calls || rejected != 0 {
+		t.Errorf("succeeding calls: allowed, rejected = %d, %d, want %d, 0", allowed, rejected, workers*calls)
+	}
+	if got, want := b.State(), exp.BreakerClosed; got != want {
+		t.Errorf("State() = %v, want %v", got, want)
+	}
+
+	// Failures trip it after 10, but calls already allowed by then (at most
+	// one in flight per other worker) still go ahead.
+	allowed, rejected := run(fail)
+	if allowed < 10 || allowed > 10+workers-1 {
+		t.Errorf("failing calls: allowed = %d, want in [10, %d]", allowed, 10+workers-1)
+	}
+	if allowed+rejected != workers*calls {
+		t.Errorf("failing calls: allowed + rejected = %d, want %d", allowed+rejected, workers*calls)
+	}
+	if got, want := b.State(), exp.BreakerOpen; got != want {
+		t.Errorf("State() = %v, want %v", got, want)
+	}
+	if diff := cmp.Diff(changes, []string{"closed->open"}); diff != "" {
+		t.Errorf("state changes diff (-got +want):\n%s", diff)
+	}
+}
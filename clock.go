@@ -0,0 +1,45 @@
+package exp
+
+import "time"
+
+// Clock is a source of time and timers. Backoff uses a Clock so that retry
+// schedules can be tested deterministically; see the exptest package for a
+// fake implementation.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// NewTimer creates a new Timer that sends the current time on its
+	// channel after at least duration d.
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is the subset of the behaviour of *time.Timer needed by Clock.
+type Timer interface {
+	// C returns the channel on which the time is delivered.
+	C() <-chan time.Time
+
+	// Stop prevents the Timer from firing. It returns true if the call stops
+	// the timer, false if the timer has already expired or been stopped.
+	Stop() bool
+}
+
+// clockOrSystem returns c, or SystemClock if c is nil.
+func clockOrSystem(c Clock) Clock {
+	if c == nil {
+		return SystemClock
+	}
+	return c
+}
+
+// SystemClock implements Clock using the time package.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                 { return time.Now() }
+func (systemClock) NewTimer(d time.Duration) Timer { return systemTimer{time.NewTimer(d)} }
+
+type systemTimer struct{ *time.Timer }
+
+func (t systemTimer) C() <-chan time.Time { return t.Timer.C }
@@ -0,0 +1,186 @@
+package exp_test
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+
+	"drjosh.dev/exp"
+	"drjosh.dev/exp/exptest"
+	"github.com/google/go-cmp/cmp"
+)
+
+var epoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// schedule collects the waits between the times produced by seq, by advancing
+// the fake clock to each pending timer in turn.
+func schedule(t *testing.T, clock *exptest.Clock, seq func(yield func(time.Time) bool)) []time.Duration {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	times := make(chan time.Time)
+	go func() {
+		defer cancel()
+		defer close(times)
+		seq(func(tm time.Time) bool {
+			times <- tm
+			return true
+		})
+	}()
+
+	var got []time.Duration
+	prev := <-times
+	for {
+		// Either seq starts waiting on a timer, or it finishes.
+		if clock.BlockUntilContext(ctx, 1) != nil {
+			return got
+		}
+		clock.AdvanceNext()
+		tm := <-times
+		got = append(got, tm.Sub(prev))
+		prev = tm
+	}
+}
+
+func TestBackoffRetryFakeClock(t *testing.T) {
+	clock := exptest.NewClock(epoch)
+	b := exp.Backoff{
+		Count:   6,
+		Initial: time.Second,
+		Grow:    3,
+		Max:     30 * time.Second,
+		Jitter:  exp.NoJitter,
+		Clock:   clock,
+	}
+	got := schedule(t, clock, b.Retry(context.Background()))
+	want := []time.Duration{
+		1 * time.Second,
+		3 * time.Second,
+		9 * time.Second,
+		27 * time.Second,
+		30 * time.Second,
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Retry schedule diff (-got +want):\n%s", diff)
+	}
+}
+
+func TestBackoffRetryChanFakeClockJitter(t *testing.T) {
+	clock := exptest.NewClock(epoch)
+	b := exp.Backoff{
+		Count:   5,
+		Initial: time.Second,
+		Grow:    2,
+		Jitter:  exp.FullJitter,
+		Clock:   clock,
+		Rand:    rand.New(rand.NewSource(42)),
+	}
+	ch := b.RetryChan(context.Background())
+	got := schedule(t, clock, func(yield func(time.Time) bool) {
+		for tm := range ch {
+			yield(tm)
+		}
+	})
+
+	// The same seed should give the same schedule.
+	r := rand.New(rand.NewSource(42))
+	var want []time.Duration
+	for n, ceil := 0, time.Second; n < 4; n, ceil = n+1, 2*ceil {
+		want = append(want, time.Duration(r.Int63n(int64(ceil))))
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("RetryChan schedule diff (-got +want):\n%s", diff)
+	}
+}
+
+func TestBackoffBudgetFakeClock(t *testing.T) {
+	clock := exptest.NewClock(epoch)
+	b := exp.Backoff{
+		Initial: 10 * time.Second,
+		Jitter:  exp.NoJitter,
+		Budget:  35 * time.Second,
+		Clock:   clock,
+	}
+	got := schedule(t, clock, b.Retry(context.Background()))
+	want := []time.Duration{10 * time.Second, 10 * time.Second, 10 * time.Second}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Retry schedule diff (-got +want):\n%s", diff)
+	}
+	if got, want := clock.Now().Sub(epoch), 30*time.Second; got != want {
+		t.Errorf("elapsed time = %v, want %v", got, want)
+	}
+}
+
+func TestDoFakeClock(t *testing.T) {
+	clock := exptest.NewClock(epoch)
+	var delays []time.Duration
+	b := exp.Backoff{
+		Count:   4,
+		Initial: time.Second,
+		Grow:    2,
+		Jitter:  exp.NoJitter,
+		Clock:   clock,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var err error
+	go func() {
+		defer cancel()
+		err = exp.Do(context.Background(), b, func(context.Context) error {
+			if len(delays) == 1 {
+				return exp.RetryAfter(errors.New("busy"), time.Minute)
+			}
+			return errors.New("nope")
+		}, exp.WithOnRetry(func(_ int, _ error, d time.Duration) {
+			delays = append(delays, d)
+		}))
+	}()
+	for clock.BlockUntilContext(ctx, 1) == nil {
+		clock.AdvanceNext()
+	}
+
+	if err == nil {
+		t.Errorf("Do() = nil, want error")
+	}
+	want := []time.Duration{time.Second, time.Minute, 4 * time.Second}
+	if diff := cmp.Diff(delays, want); diff != "" {
+		t.Errorf("Do delays diff (-got +want):\n%s", diff)
+	}
+	if got, want := clock.Now().Sub(epoch), time.Minute+5*time.Second; got != want {
+		t.Errorf("elapsed time = %v, want %v", got, want)
+	}
+}
+
+func TestRangeChBatchTimeout(t *testing.T) {
+	clock := exptest.NewClock(epoch)
+	ch := make(chan int)
+	batches := make(chan []int)
+	errc := make(chan error, 1)
+	go func() {
+		defer close(batches)
+		errc <- exp.RangeChBatchClock(context.Background(), clock, ch, 100, 10*time.Millisecond, func(batch []int) error {
+			batches <- slices.Clone(batch)
+			return nil
+		})
+	}()
+
+	ch <- 1
+	ch <- 2
+	clock.BlockUntil(1) // the timeout timer started when 1 was received
+	clock.AdvanceNext()
+	got := [][]int{<-batches}
+	ch <- 3
+	close(ch)
+	for b := range batches {
+		got = append(got, b)
+	}
+	if err := <-errc; err != nil {
+		t.Fatalf("RangeChBatch() = %v", err)
+	}
+	want := [][]int{{1, 2}, {3}}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("RangeChBatch batches diff (-got +want):\n%s", diff)
+	}
+}
@@ -3,6 +3,9 @@ package exp
 import (
 	"context"
 	"errors"
+	"iter"
+	"reflect"
+	"time"
 )
 
 // Break is used to exit the RangeCh loop early without error.
@@ -28,3 +31,175 @@ func RangeCh[T any](ctx context.Context, ch <-chan T, f func(T) error) error {
 		}
 	}
 }
+
+// RangeChN ranges over several channels in a context-aware way, until all of
+// them are closed. When more than one channel is ready, one is chosen at
+// random, so that a busy channel cannot starve the others. As with RangeCh,
+// f can return Break to stop early without error. Nil channels are ignored
+// (rather than blocking forever, as they would in a select statement).
+func RangeChN[T any](ctx context.Context, f func(T) error, chs ...<-chan T) error {
+	cases := make([]reflect.SelectCase, 1, len(chs)+1)
+	cases[0] = reflect.SelectCase{
+		Dir:  reflect.SelectRecv,
+		Chan: reflect.ValueOf(ctx.Done()),
+	}
+	for _, ch := range chs {
+		if ch == nil {
+			continue
+		}
+		cases = append(cases, reflect.SelectCase{
+			Dir:  reflect.SelectRecv,
+			Chan: reflect.ValueOf(ch),
+		})
+	}
+
+	for open := len(cases) - 1; open > 0; {
+		i, v, ok := reflect.Select(cases)
+		if i == 0 {
+			return ctx.Err()
+		}
+		if !ok {
+			// A nil channel is never ready, which removes it from the select.
+			cases[i].Chan = reflect.Value{}
+			open--
+			continue
+		}
+		// If T is an interface type and a nil value was received,
+		// v.Interface() returns a nil any, which would fail a checked
+		// type assertion.
+		t, _ := v.Interface().(T)
+		if err := f(t); err != nil {
+			if errors.Is(err, Break) {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// RecvCtx receives a single value from ch in a context-aware way. ok is false
+// if ch was closed. If the context is done first, it returns the context's
+// error.
+func RecvCtx[T any](ctx context.Context, ch <-chan T) (t T, ok bool, err error) {
+	select {
+	case t, ok = <-ch:
+		return t, ok, nil
+	case <-ctx.Done():
+		return t, false, ctx.Err()
+	}
+}
+
+// SendCtx sends a single value on ch in a context-aware way. If the context is
+// done first, it returns the context's error.
+func SendCtx[T any](ctx context.Context, ch chan<- T, t T) error {
+	select {
+	case ch <- t:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// RangeChBatch ranges over a channel in a context-aware way, delivering values
+// to f in batches. A batch is delivered when it reaches size values, or when
+// timeout has elapsed since the first value in the batch was received
+// (if timeout > 0), or when ch is closed (if the batch is non-empty).
+// As with RangeCh, f can return Break to stop early without error.
+// If the context is done first, RangeChBatch returns the context's error, and
+// any values received into a partial batch are discarded (f is not called
+// with them). The slice passed to f is reused between calls, so f should copy it if it
+// needs to retain it.
+func RangeChBatch[T any](ctx context.Context, ch <-chan T, size int, timeout time.Duration, f func([]T) error) error {
+	return rangeChBatch(ctx, SystemClock, ch, size, timeout, f)
+}
+
+// rangeChBatch implements RangeChBatch using clock for the batch timeout.
+func rangeChBatch[T any](ctx context.Context, clock Clock, ch <-chan T, size int, timeout time.Duration, f func([]T) error) error {
+	if size < 1 {
+		size = 1
+	}
+	batch := make([]T, 0, size)
+	var timer Timer
+	var timeoutC <-chan time.Time
+
+	flush := func() error {
+		if timer != nil {
+			timer.Stop()
+			timer, timeoutC = nil, nil
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+		err := f(batch)
+		batch = batch[:0]
+		return err
+	}
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+
+	for {
+		var err error
+		select {
+		case t, open := <-ch:
+			if !open {
+				err = flush()
+				if err == nil {
+					return nil
+				}
+				break
+			}
+			batch = append(batch, t)
+			if len(batch) >= size {
+				err = flush()
+				break
+			}
+			if len(batch) == 1 && timeout > 0 {
+				timer = clock.NewTimer(timeout)
+				timeoutC = timer.C()
+			}
+
+		case <-timeoutC:
+			timer, timeoutC = nil, nil
+			err = flush()
+
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		if err != nil {
+			if errors.Is(err, Break) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// RecvSeq returns an iterator that ranges over a channel in a context-aware
+// way. Each value is yielded with a nil error. If the context is done before
+// the channel is closed, the context's error is yielded (with the zero value
+// for T) and the iterator stops.
+func RecvSeq[T any](ctx context.Context, ch <-chan T) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for {
+			select {
+			case t, open := <-ch:
+				if !open {
+					return
+				}
+				if !yield(t, nil) {
+					return
+				}
+
+			case <-ctx.Done():
+				var zero T
+				yield(zero, ctx.Err())
+				return
+			}
+		}
+	}
+}
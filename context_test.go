@@ -3,6 +3,7 @@ package exp
 import (
 	"context"
 	"fmt"
+	"testing"
 	"time"
 )
 
@@ -50,4 +51,206 @@ func ExampleRangeCh() {
 	<-wait
 	
 	// Output: Sum: 55
-}
\ No newline at end of file
+}
+
+func ExampleRangeChN() {
+	ctx := context.Background()
+
+	a, b := make(chan int), make(chan int)
+	go func() {
+		defer close(a)
+		for i := 1; i <= 5; i++ {
+			a <- i
+		}
+	}()
+	go func() {
+		defer close(b)
+		for i := 6; i <= 10; i++ {
+			b <- i
+		}
+	}()
+
+	sum := 0
+	err := RangeChN(ctx, func(n int) error {
+		sum += n
+		return nil
+	}, a, b)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	fmt.Printf("Sum: %d\n", sum)
+
+	// Output: Sum: 55
+}
+
+func ExampleRangeChBatch() {
+	ctx := context.Background()
+
+	ch := make(chan int)
+	go func() {
+		defer close(ch)
+		for i := 1; i <= 10; i++ {
+			ch <- i
+		}
+	}()
+
+	err := RangeChBatch(ctx, ch, 4, time.Hour, func(batch []int) error {
+		fmt.Println(batch)
+		return nil
+	})
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	// Output:
+	// [1 2 3 4]
+	// [5 6 7 8]
+	// [9 10]
+}
+
+func ExampleRecvSeq() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := make(chan int)
+	go func() {
+		for i := 1; ; i++ {
+			if err := SendCtx(ctx, ch, i); err != nil {
+				return
+			}
+		}
+	}()
+
+	for n, err := range RecvSeq(ctx, ch) {
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			break
+		}
+		fmt.Println(n)
+		if n == 3 {
+			cancel()
+		}
+	}
+
+	// Output:
+	// 1
+	// 2
+	// 3
+	// Error: context canceled
+}
+
+func TestRangeChBatchCancelDiscards(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan int)
+	errc := make(chan error, 1)
+	calls := 0
+	go func() {
+		errc <- RangeChBatch(ctx, ch, 10, 0, func([]int) error {
+			calls++
+			return nil
+		})
+	}()
+
+	ch <- 1
+	ch <- 2 // both values are now in the pending batch
+	cancel()
+	if err := <-errc; err != context.Canceled {
+		t.Errorf("RangeChBatch() = %v, want %v", err, context.Canceled)
+	}
+	if calls != 0 {
+		t.Errorf("f called %d times, want 0 (partial batch discarded)", calls)
+	}
+}
+
+func TestRangeChNBreak(t *testing.T) {
+	ctx := context.Background()
+
+	ch := make(chan int)
+	go func() {
+		for i := 0; ; i++ {
+			if err := SendCtx(ctx, ch, i); err != nil {
+				return
+			}
+		}
+	}()
+
+	count := 0
+	err := RangeChN(ctx, func(int) error {
+		count++
+		if count == 5 {
+			return Break
+		}
+		return nil
+	}, ch)
+	if err != nil {
+		t.Errorf("RangeChN() = %v, want nil", err)
+	}
+	if count != 5 {
+		t.Errorf("count = %d, want 5", count)
+	}
+}
+
+func TestRangeChNNilValue(t *testing.T) {
+	ch := make(chan error, 2)
+	ch <- nil
+	ch <- Break
+	close(ch)
+
+	count := 0
+	err := RangeChN(context.Background(), func(err error) error {
+		count++
+		return err
+	}, ch)
+	if err != nil {
+		t.Errorf("RangeChN() = %v, want nil", err)
+	}
+	if count != 2 {
+		t.Errorf("count = %d, want 2", count)
+	}
+}
+
+func TestRangeChNNilChannel(t *testing.T) {
+	ch := make(chan int, 2)
+	ch <- 1
+	ch <- 2
+	close(ch)
+
+	sum := 0
+	err := RangeChN(context.Background(), func(n int) error {
+		sum += n
+		return nil
+	}, nil, ch, nil)
+	if err != nil {
+		t.Errorf("RangeChN() = %v, want nil", err)
+	}
+	if sum != 3 {
+		t.Errorf("sum = %d, want 3", sum)
+	}
+
+	if err := RangeChN[int](context.Background(), nil, nil); err != nil {
+		t.Errorf("RangeChN(nil) = %v, want nil", err)
+	}
+}
+
+func TestRecvCtx(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	ch := make(chan int, 1)
+	ch <- 42
+	if n, ok, err := RecvCtx(ctx, ch); n != 42 || !ok || err != nil {
+		t.Errorf("RecvCtx() = (%d, %t, %v), want (42, true, nil)", n, ok, err)
+	}
+	close(ch)
+	if n, ok, err := RecvCtx(ctx, ch); n != 0 || ok || err != nil {
+		t.Errorf("RecvCtx(closed) = (%d, %t, %v), want (0, false, nil)", n, ok, err)
+	}
+
+	cancel()
+	if _, _, err := RecvCtx(ctx, make(chan int)); err != context.Canceled {
+		t.Errorf("RecvCtx(cancelled) error = %v, want %v", err, context.Canceled)
+	}
+	if err := SendCtx(ctx, make(chan int), 1); err != context.Canceled {
+		t.Errorf("SendCtx(cancelled) = %v, want %v", err, context.Canceled)
+	}
+}
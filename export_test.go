@@ -0,0 +1,14 @@
+package exp
+
+import (
+	"context"
+	"time"
+)
+
+// Exported for testing with exptest, which cannot be imported by internal
+// tests without an import cycle.
+
+// RangeChBatchClock is RangeChBatch using clock for the batch timeout.
+func RangeChBatchClock[T any](ctx context.Context, clock Clock, ch <-chan T, size int, timeout time.Duration, f func([]T) error) error {
+	return rangeChBatch(ctx, clock, ch, size, timeout, f)
+}
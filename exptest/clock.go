@@ -0,0 +1,154 @@
+// Package exptest provides helpers for testing code that uses the exp package.
+package exptest
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"drjosh.dev/exp"
+)
+
+// Clock is a fake exp.Clock. Time only passes when Advance (or AdvanceNext)
+// is called, and timers fire only when the fake time reaches them. It is safe
+// for concurrent use.
+type Clock struct {
+	mu     sync.Mutex
+	cond   sync.Cond
+	now    time.Time
+	timers []*timer
+}
+
+var _ exp.Clock = (*Clock)(nil)
+
+// NewClock returns a new fake clock with the current time set to now.
+func NewClock(now time.Time) *Clock {
+	c := &Clock{now: now}
+	c.cond.L = &c.mu
+	return c
+}
+
+// Now returns the fake current time.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// NewTimer returns a fake timer that fires when the fake time reaches
+// Now() + d. If d <= 0 the timer fires immediately.
+func (c *Clock) NewTimer(d time.Duration) exp.Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &timer{
+		clock: c,
+		when:  c.now.Add(d),
+		ch:    make(chan time.Time, 1),
+	}
+	if d <= 0 {
+		t.ch <- c.now
+		return t
+	}
+	c.timers = append(c.timers, t)
+	c.cond.Broadcast()
+	return t
+}
+
+// Advance moves the fake time forward by d, firing any timers that are due.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	c.fire()
+}
+
+// AdvanceNext moves the fake time forward to when the earliest pending timer
+// is due, fires it (and any others due at the same time), and returns how far
+// the time moved. If there are no pending timers, it does nothing and
+// returns 0.
+func (c *Clock) AdvanceNext() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.timers) == 0 {
+		return 0
+	}
+	next := slices.MinFunc(c.timers, func(a, b *timer) int { return a.when.Compare(b.when) })
+	d := next.when.Sub(c.now)
+	c.now = next.when
+	c.fire()
+	return d
+}
+
+// Pending returns the number of timers that have not yet fired or been
+// stopped.
+func (c *Clock) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.timers)
+}
+
+// BlockUntil blocks until there are at least n pending timers. This is useful
+// for waiting until code under test (in another goroutine) is waiting on the
+// clock.
+func (c *Clock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.timers) < n {
+		c.cond.Wait()
+	}
+}
+
+// BlockUntilContext is like BlockUntil, but returns ctx.Err() if ctx is done
+// before there are n pending timers. This is useful when the code under test
+// may finish instead of waiting on the clock.
+func (c *Clock) BlockUntilContext(ctx context.Context, n int) error {
+	stop := context.AfterFunc(ctx, func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.cond.Broadcast()
+	})
+	defer stop()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.timers) < n {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		c.cond.Wait()
+	}
+	return nil
+}
+
+// fire fires and removes all timers that are due. c.mu must be held.
+func (c *Clock) fire() {
+	c.timers = slices.DeleteFunc(c.timers, func(t *timer) bool {
+		if t.when.After(c.now) {
+			return false
+		}
+		t.ch <- c.now
+		return true
+	})
+}
+
+// timer is a fake exp.Timer.
+type timer struct {
+	clock *Clock
+	when  time.Time
+	ch    chan time.Time
+}
+
+func (t *timer) C() <-chan time.Time { return t.ch }
+
+func (t *timer) Stop() bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	i := slices.Index(c.timers, t)
+	if i < 0 {
+		return false
+	}
+	c.timers = slices.Delete(c.timers, i, i+1)
+	return true
+}
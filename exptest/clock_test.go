@@ -0,0 +1,71 @@
+package exptest
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewClock(start)
+
+	t1 := c.NewTimer(time.Second)
+	t2 := c.NewTimer(2 * time.Second)
+	t3 := c.NewTimer(3 * time.Second)
+	if got, want := c.Pending(), 3; got != want {
+		t.Errorf("Pending() = %d, want %d", got, want)
+	}
+
+	if !t3.Stop() {
+		t.Errorf("t3.Stop() = false, want true")
+	}
+	if t3.Stop() {
+		t.Errorf("t3.Stop() again = true, want false")
+	}
+
+	c.Advance(1500 * time.Millisecond)
+	select {
+	case got := <-t1.C():
+		if want := start.Add(1500 * time.Millisecond); !got.Equal(want) {
+			t.Errorf("t1 fired at %v, want %v", got, want)
+		}
+	default:
+		t.Errorf("t1 did not fire after Advance")
+	}
+	select {
+	case <-t2.C():
+		t.Errorf("t2 fired early")
+	default:
+	}
+
+	if got, want := c.AdvanceNext(), 500*time.Millisecond; got != want {
+		t.Errorf("AdvanceNext() = %v, want %v", got, want)
+	}
+	select {
+	case <-t2.C():
+	default:
+		t.Errorf("t2 did not fire after AdvanceNext")
+	}
+	if got, want := c.Pending(), 0; got != want {
+		t.Errorf("Pending() = %d, want %d", got, want)
+	}
+	if got, want := c.AdvanceNext(), time.Duration(0); got != want {
+		t.Errorf("AdvanceNext() with no timers = %v, want %v", got, want)
+	}
+}
+
+func TestClockBlockUntilContext(t *testing.T) {
+	c := NewClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	go c.NewTimer(time.Second)
+	if err := c.BlockUntilContext(context.Background(), 1); err != nil {
+		t.Errorf("BlockUntilContext() = %v, want nil", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go cancel()
+	if err := c.BlockUntilContext(ctx, 2); err != context.Canceled {
+		t.Errorf("BlockUntilContext(cancelled) = %v, want %v", err, context.Canceled)
+	}
+}
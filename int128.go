@@ -2,13 +2,34 @@ package exp
 
 import (
 	"cmp"
+	"math"
 	"math/bits"
+	"strconv"
 )
 
+// Uint128 is an unsigned 128-bit integer. Arithmetic wraps around on overflow,
+// like the built-in unsigned integer types.
 type Uint128 struct{ hi, lo uint64 }
 
+// MaxUint128 is the largest value representable by a Uint128.
+var MaxUint128 = Uint128{hi: math.MaxUint64, lo: math.MaxUint64}
+
+// NewUint128 returns the Uint128 with the given high and low 64-bit halves.
 func NewUint128(hi, lo uint64) Uint128 { return Uint128{hi: hi, lo: lo} }
 
+// Uint128From64 converts a uint64 into a Uint128.
+func Uint128From64(x uint64) Uint128 { return Uint128{lo: x} }
+
+// Hi returns the high 64 bits of x.
+func (x Uint128) Hi() uint64 { return x.hi }
+
+// Lo returns the low 64 bits of x.
+func (x Uint128) Lo() uint64 { return x.lo }
+
+// IsZero reports whether x == 0.
+func (x Uint128) IsZero() bool { return x.hi == 0 && x.lo == 0 }
+
+// Add returns x + y.
 func (x Uint128) Add(y Uint128) (z Uint128) {
 	l, c := bits.Add64(x.lo, y.lo, 0)
 	z.lo = l
@@ -16,6 +37,7 @@ func (x Uint128) Add(y Uint128) (z Uint128) {
 	return z
 }
 
+// Sub returns x - y.
 func (x Uint128) Sub(y Uint128) (z Uint128) {
 	l, b := bits.Sub64(x.lo, y.lo, 0)
 	z.lo = l
@@ -23,12 +45,14 @@ func (x Uint128) Sub(y Uint128) (z Uint128) {
 	return z
 }
 
+// Mul returns x * y.
 func (x Uint128) Mul(y Uint128) (z Uint128) {
 	z.hi, z.lo = bits.Mul64(x.lo, y.lo)
 	z.hi += x.hi*y.lo + x.lo*y.hi
 	return z
 }
 
+// Cmp compares x and y, returning -1 if x < y, 0 if x == y, and +1 if x > y.
 func (x Uint128) Cmp(y Uint128) int {
 	if x.hi != y.hi {
 		return cmp.Compare(x.hi, y.hi)
@@ -36,20 +60,432 @@ func (x Uint128) Cmp(y Uint128) int {
 	return cmp.Compare(x.lo, y.lo)
 }
 
-// func (x Uint128) Div(y uint64) (z Uint128) {
+// QuoRem64 returns the quotient x/y and remainder x%y.
+// It panics if y == 0.
+func (x Uint128) QuoRem64(y uint64) (q Uint128, r uint64) {
+	if x.hi < y {
+		q.lo, r = bits.Div64(x.hi, x.lo, y)
+		return q, r
+	}
+	q.hi, r = bits.Div64(0, x.hi, y)
+	q.lo, r = bits.Div64(r, x.lo, y)
+	return q, r
+}
+
+// Div64 returns the quotient x/y. It panics if y == 0.
+func (x Uint128) Div64(y uint64) Uint128 {
+	q, _ := x.QuoRem64(y)
+	return q
+}
+
+// Mod64 returns the remainder x%y. It panics if y == 0.
+func (x Uint128) Mod64(y uint64) uint64 {
+	_, r := x.QuoRem64(y)
+	return r
+}
+
+// QuoRem returns the quotient x/y and remainder x%y.
+// It panics if y == 0.
+func (x Uint128) QuoRem(y Uint128) (q, r Uint128) {
+	if y.hi == 0 {
+		q, r64 := x.QuoRem64(y.lo)
+		return q, Uint128{lo: r64}
+	}
+	// Based on the "divlu" approach in Hacker's Delight: normalise y so that
+	// its top bit is set, estimate the quotient (which fits in 64 bits since
+	// y >= 2^64) from the top 64 bits, then correct the estimate.
+	n := bits.LeadingZeros64(y.hi)
+	v1 := y.Lsh(n).hi
+	u1 := x.Rsh(1)
+	tq, _ := bits.Div64(u1.hi, u1.lo, v1)
+	tq >>= 63 - n
+	if tq != 0 {
+		tq--
+	}
+	q = Uint128{lo: tq}
+	r = x.Sub(y.Mul(q))
+	if r.Cmp(y) >= 0 {
+		q = q.Add(Uint128{lo: 1})
+		r = r.Sub(y)
+	}
+	return q, r
+}
+
+// Div returns the quotient x/y. It panics if y == 0.
+func (x Uint128) Div(y Uint128) Uint128 {
+	q, _ := x.QuoRem(y)
+	return q
+}
+
+// Mod returns the remainder x%y. It panics if y == 0.
+func (x Uint128) Mod(y Uint128) Uint128 {
+	_, r := x.QuoRem(y)
+	return r
+}
+
+// And returns x & y.
+func (x Uint128) And(y Uint128) Uint128 { return Uint128{hi: x.hi & y.hi, lo: x.lo & y.lo} }
 
-// }
+// Or returns x | y.
+func (x Uint128) Or(y Uint128) Uint128 { return Uint128{hi: x.hi | y.hi, lo: x.lo | y.lo} }
 
+// Xor returns x ^ y.
+func (x Uint128) Xor(y Uint128) Uint128 { return Uint128{hi: x.hi ^ y.hi, lo: x.lo ^ y.lo} }
+
+// AndNot returns x &^ y.
+func (x Uint128) AndNot(y Uint128) Uint128 { return Uint128{hi: x.hi &^ y.hi, lo: x.lo &^ y.lo} }
+
+// Not returns ^x.
+func (x Uint128) Not() Uint128 { return Uint128{hi: ^x.hi, lo: ^x.lo} }
+
+// Lsh returns x << k. Like the built-in shift operators, shifting by 128 or
+// more produces 0. It panics if k < 0.
 func (x Uint128) Lsh(k int) Uint128 {
-	x.hi <<= k
-	x.hi += x.lo >> (64 - k)
+	switch {
+	case k < 0:
+		panic("negative shift amount")
+	case k >= 128:
+		return Uint128{}
+	case k >= 64:
+		return Uint128{hi: x.lo << (k - 64)}
+	}
+	x.hi = x.hi<<k | x.lo>>(64-k)
 	x.lo <<= k
 	return x
 }
 
+// Rsh returns x >> k. Like the built-in shift operators, shifting by 128 or
+// more produces 0. It panics if k < 0.
 func (x Uint128) Rsh(k int) Uint128 {
-	x.lo >>= k
-	x.lo += x.hi << (64 - k)
+	switch {
+	case k < 0:
+		panic("negative shift amount")
+	case k >= 128:
+		return Uint128{}
+	case k >= 64:
+		return Uint128{lo: x.hi >> (k - 64)}
+	}
+	x.lo = x.lo>>k | x.hi<<(64-k)
 	x.hi >>= k
 	return x
 }
+
+// LeadingZeros returns the number of leading zero bits in x.
+// The result is 128 for x == 0.
+func (x Uint128) LeadingZeros() int {
+	if x.hi != 0 {
+		return bits.LeadingZeros64(x.hi)
+	}
+	return 64 + bits.LeadingZeros64(x.lo)
+}
+
+// TrailingZeros returns the number of trailing zero bits in x.
+// The result is 128 for x == 0.
+func (x Uint128) TrailingZeros() int {
+	if x.lo != 0 {
+		return bits.TrailingZeros64(x.lo)
+	}
+	return 64 + bits.TrailingZeros64(x.hi)
+}
+
+// OnesCount returns the number of one bits ("population count") in x.
+func (x Uint128) OnesCount() int {
+	return bits.OnesCount64(x.hi) + bits.OnesCount64(x.lo)
+}
+
+// Len returns the minimum number of bits required to represent x.
+// The result is 0 for x == 0.
+func (x Uint128) Len() int { return 128 - x.LeadingZeros() }
+
+// String returns x in base 10.
+func (x Uint128) String() string { return x.Text(10) }
+
+// Text returns x in the given base, which must be between 2 and 36
+// inclusive. Digits above 9 are written as lower-case letters.
+func (x Uint128) Text(base int) string {
+	return string(x.appendText(nil, base))
+}
+
+// appendText appends the digits of x in the given base to buf.
+func (x Uint128) appendText(buf []byte, base int) []byte {
+	if base < 2 || base > 36 {
+		panic("exp: illegal Uint128 base")
+	}
+	if x.hi == 0 {
+		return strconv.AppendUint(buf, x.lo, base)
+	}
+	var digits [128]byte
+	i := len(digits)
+	for x.hi != 0 {
+		var r uint64
+		x, r = x.QuoRem64(uint64(base))
+		i--
+		digits[i] = digitChars[r]
+	}
+	buf = strconv.AppendUint(buf, x.lo, base)
+	return append(buf, digits[i:]...)
+}
+
+const digitChars = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// ParseUint128 interprets a string s in the given base (0, or 2 to 36) and
+// returns the corresponding value. As with strconv.ParseUint, if base is 0
+// the base is implied by the string's prefix ("0b", "0o" or "0", "0x"), and
+// otherwise defaults to 10. Errors are of type *strconv.NumError.
+func ParseUint128(s string, base int) (Uint128, error) {
+	const fn = "ParseUint128"
+	x, err := parseUint128(s, base)
+	if err != nil {
+		return x, &strconv.NumError{Func: fn, Num: s, Err: err}
+	}
+	return x, nil
+}
+
+// parseUint128 implements ParseUint128 without wrapping errors.
+func parseUint128(s string, base int) (Uint128, error) {
+	if s == "" {
+		return Uint128{}, strconv.ErrSyntax
+	}
+	if base == 0 {
+		base = 10
+		if s[0] == '0' {
+			switch {
+			case len(s) >= 3 && lower(s[1]) == 'b':
+				base, s = 2, s[2:]
+			case len(s) >= 3 && lower(s[1]) == 'o':
+				base, s = 8, s[2:]
+			case len(s) >= 3 && lower(s[1]) == 'x':
+				base, s = 16, s[2:]
+			default:
+				base, s = 8, s[1:]
+				if s == "" {
+					return Uint128{}, nil
+				}
+			}
+		}
+	}
+	if base < 2 || base > 36 {
+		return Uint128{}, strconv.ErrSyntax
+	}
+
+	cutoff := MaxUint128.Div64(uint64(base))
+	var x Uint128
+	for i := 0; i < len(s); i++ {
+		var d byte
+		switch c := s[i]; {
+		case '0' <= c && c <= '9':
+			d = c - '0'
+		case 'a' <= lower(c) && lower(c) <= 'z':
+			d = lower(c) - 'a' + 10
+		default:
+			return Uint128{}, strconv.ErrSyntax
+		}
+		if int(d) >= base {
+			return Uint128{}, strconv.ErrSyntax
+		}
+		if x.Cmp(cutoff) > 0 {
+			return MaxUint128, strconv.ErrRange
+		}
+		x = x.Mul(Uint128{lo: uint64(base)})
+		x1 := x.Add(Uint128{lo: uint64(d)})
+		if x1.Cmp(x) < 0 {
+			return MaxUint128, strconv.ErrRange
+		}
+		x = x1
+	}
+	return x, nil
+}
+
+// lower returns the lower-case version of an ASCII letter (and garbage for
+// other bytes, which is fine for comparison against letters).
+func lower(c byte) byte { return c | ('x' - 'X') }
+
+// Int128 is a signed 128-bit integer, stored in two's complement form.
+// Arithmetic wraps around on overflow, like the built-in signed integer
+// types.
+type Int128 struct{ hi, lo uint64 }
+
+var (
+	// MaxInt128 is the largest value representable by an Int128.
+	MaxInt128 = Int128{hi: math.MaxInt64, lo: math.MaxUint64}
+
+	// MinInt128 is the smallest value representable by an Int128.
+	MinInt128 = Int128{hi: 1 << 63}
+)
+
+// NewInt128 returns the Int128 with the given high (signed) and low 64-bit
+// halves.
+func NewInt128(hi int64, lo uint64) Int128 { return Int128{hi: uint64(hi), lo: lo} }
+
+// Int128From64 converts an int64 into an Int128.
+func Int128From64(x int64) Int128 { return Int128{hi: uint64(x >> 63), lo: uint64(x)} }
+
+// Hi returns the high 64 bits of x.
+func (x Int128) Hi() int64 { return int64(x.hi) }
+
+// Lo returns the low 64 bits of x.
+func (x Int128) Lo() uint64 { return x.lo }
+
+// IsZero reports whether x == 0.
+func (x Int128) IsZero() bool { return x.hi == 0 && x.lo == 0 }
+
+// Sign returns -1 if x < 0, 0 if x == 0, and +1 if x > 0.
+func (x Int128) Sign() int {
+	switch {
+	case int64(x.hi) < 0:
+		return -1
+	case x.IsZero():
+		return 0
+	}
+	return 1
+}
+
+// Uint128 returns x reinterpreted as a Uint128 (two's complement).
+func (x Int128) Uint128() Uint128 { return Uint128(x) }
+
+// Int128 returns x reinterpreted as an Int128 (two's complement).
+func (x Uint128) Int128() Int128 { return Int128(x) }
+
+// Add returns x + y.
+func (x Int128) Add(y Int128) Int128 { return Int128(Uint128(x).Add(Uint128(y))) }
+
+// Sub returns x - y.
+func (x Int128) Sub(y Int128) Int128 { return Int128(Uint128(x).Sub(Uint128(y))) }
+
+// Mul returns x * y.
+func (x Int128) Mul(y Int128) Int128 { return Int128(Uint128(x).Mul(Uint128(y))) }
+
+// Neg returns -x. As with the built-in types, -MinInt128 == MinInt128.
+func (x Int128) Neg() Int128 { return Int128(Uint128(x).Not().Add(Uint128{lo: 1})) }
+
+// Abs returns the absolute value of x as a Uint128 (so that the absolute
+// value of MinInt128 is representable).
+func (x Int128) Abs() Uint128 {
+	if x.Sign() < 0 {
+		return Uint128(x.Neg())
+	}
+	return Uint128(x)
+}
+
+// Cmp compares x and y, returning -1 if x < y, 0 if x == y, and +1 if x > y.
+func (x Int128) Cmp(y Int128) int {
+	if x.hi != y.hi {
+		return cmp.Compare(int64(x.hi), int64(y.hi))
+	}
+	return cmp.Compare(x.lo, y.lo)
+}
+
+// QuoRem returns the quotient x/y and remainder x%y, with the same truncated
+// division semantics as the built-in / and % operators (the quotient rounds
+// toward zero, and the remainder has the sign of x). It panics if y == 0.
+func (x Int128) QuoRem(y Int128) (q, r Int128) {
+	uq, ur := x.Abs().QuoRem(y.Abs())
+	q, r = Int128(uq), Int128(ur)
+	if x.Sign()*y.Sign() < 0 {
+		q = q.Neg()
+	}
+	if x.Sign() < 0 {
+		r = r.Neg()
+	}
+	return q, r
+}
+
+// Div returns the quotient x/y, truncated toward zero. It panics if y == 0.
+func (x Int128) Div(y Int128) Int128 {
+	q, _ := x.QuoRem(y)
+	return q
+}
+
+// Mod returns the remainder x%y, which has the sign of x. It panics if
+// y == 0.
+func (x Int128) Mod(y Int128) Int128 {
+	_, r := x.QuoRem(y)
+	return r
+}
+
+// And returns x & y.
+func (x Int128) And(y Int128) Int128 { return Int128(Uint128(x).And(Uint128(y))) }
+
+// Or returns x | y.
+func (x Int128) Or(y Int128) Int128 { return Int128(Uint128(x).Or(Uint128(y))) }
+
+// Xor returns x ^ y.
+func (x Int128) Xor(y Int128) Int128 { return Int128(Uint128(x).Xor(Uint128(y))) }
+
+// AndNot returns x &^ y.
+func (x Int128) AndNot(y Int128) Int128 { return Int128(Uint128(x).AndNot(Uint128(y))) }
+
+// Not returns ^x.
+func (x Int128) Not() Int128 { return Int128(Uint128(x).Not()) }
+
+// Lsh returns x << k. It panics if k < 0.
+func (x Int128) Lsh(k int) Int128 { return Int128(Uint128(x).Lsh(k)) }
+
+// Rsh returns x >> k, which is an arithmetic shift (the sign bit is
+// replicated). It panics if k < 0.
+func (x Int128) Rsh(k int) Int128 {
+	switch {
+	case k < 0:
+		panic("negative shift amount")
+	case k >= 128:
+		k = 127
+	}
+	if k >= 64 {
+		return Int128{hi: uint64(int64(x.hi) >> 63), lo: uint64(int64(x.hi) >> (k - 64))}
+	}
+	x.lo = x.lo>>k | x.hi<<(64-k)
+	x.hi = uint64(int64(x.hi) >> k)
+	return x
+}
+
+// String returns x in base 10.
+func (x Int128) String() string { return x.Text(10) }
+
+// Text returns x in the given base, which must be between 2 and 36
+// inclusive. Digits above 9 are written as lower-case letters.
+func (x Int128) Text(base int) string {
+	var buf []byte
+	if x.Sign() < 0 {
+		buf = append(buf, '-')
+	}
+	return string(x.Abs().appendText(buf, base))
+}
+
+// ParseInt128 interprets a string s in the given base (0, or 2 to 36) and
+// returns the corresponding value. The string may begin with a leading sign,
+// "+" or "-". Base prefixes are handled as for ParseUint128.
+// Errors are of type *strconv.NumError.
+func ParseInt128(s string, base int) (Int128, error) {
+	const fn = "ParseInt128"
+	if s == "" {
+		return Int128{}, &strconv.NumError{Func: fn, Num: s, Err: strconv.ErrSyntax}
+	}
+	neg := false
+	t := s
+	switch s[0] {
+	case '+':
+		t = s[1:]
+	case '-':
+		neg, t = true, s[1:]
+	}
+	u, err := parseUint128(t, base)
+	if err != nil && err != strconv.ErrRange {
+		return Int128{}, &strconv.NumError{Func: fn, Num: s, Err: err}
+	}
+	limit := Uint128(MaxInt128)
+	if neg {
+		limit = Uint128(MinInt128)
+	}
+	if err == strconv.ErrRange || u.Cmp(limit) > 0 {
+		x := MaxInt128
+		if neg {
+			x = MinInt128
+		}
+		return x, &strconv.NumError{Func: fn, Num: s, Err: strconv.ErrRange}
+	}
+	x := Int128(u)
+	if neg {
+		x = x.Neg()
+	}
+	return x, nil
+}
@@ -1,6 +1,10 @@
 package exp
 
-import "testing"
+import (
+	"math/big"
+	"math/rand/v2"
+	"testing"
+)
 
 func TestUint128_Lsh(t *testing.T) {
 	tests := []struct {
@@ -45,3 +49,191 @@ func TestUint128_Lsh(t *testing.T) {
 		}
 	}
 }
+
+func TestUint128_Lsh_Large(t *testing.T) {
+	x := Uint128{hi: 0x0123456789abcdef, lo: 0xfedcba9876543210}
+	tests := []struct {
+		k    int
+		want Uint128
+	}{
+		{k: 0, want: x},
+		{k: 64, want: Uint128{hi: 0xfedcba9876543210, lo: 0}},
+		{k: 68, want: Uint128{hi: 0xedcba98765432100, lo: 0}},
+		{k: 127, want: Uint128{hi: 0, lo: 0}},
+		{k: 128, want: Uint128{}},
+		{k: 1000, want: Uint128{}},
+	}
+	for _, test := range tests {
+		if got := x.Lsh(test.k); got != test.want {
+			t.Errorf("(%v).Lsh(%d) = %v, want %v", x, test.k, got, test.want)
+		}
+	}
+}
+
+func TestUint128_Rsh(t *testing.T) {
+	x := Uint128{hi: 0x0123456789abcdef, lo: 0xfedcba9876543210}
+	tests := []struct {
+		k    int
+		want Uint128
+	}{
+		{k: 0, want: x},
+		{k: 4, want: Uint128{hi: 0x00123456789abcde, lo: 0xffedcba987654321}},
+		{k: 64, want: Uint128{hi: 0, lo: 0x0123456789abcdef}},
+		{k: 68, want: Uint128{hi: 0, lo: 0x00123456789abcde}},
+		{k: 128, want: Uint128{}},
+		{k: 1000, want: Uint128{}},
+	}
+	for _, test := range tests {
+		if got := x.Rsh(test.k); got != test.want {
+			t.Errorf("(%v).Rsh(%d) = %v, want %v", x, test.k, got, test.want)
+		}
+	}
+}
+
+// testRandUint128 returns a random Uint128 with a random bit length, so that
+// small values are exercised as often as large ones.
+func testRandUint128(r *rand.Rand) Uint128 {
+	x := Uint128{hi: r.Uint64(), lo: r.Uint64()}
+	return x.Rsh(r.IntN(129))
+}
+
+func TestUint128_QuoRem(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 10000 {
+		x, y := testRandUint128(r), testRandUint128(r)
+		if y.IsZero() {
+			continue
+		}
+		q, m := x.QuoRem(y)
+		wantq, wantm := new(big.Int).QuoRem(x.Big(), y.Big(), new(big.Int))
+		if q.Big().Cmp(wantq) != 0 || m.Big().Cmp(wantm) != 0 {
+			t.Errorf("(%v).QuoRem(%v) = (%v, %v), want (%v, %v)", x, y, q, m, wantq, wantm)
+		}
+		if y.hi == 0 {
+			q64, m64 := x.QuoRem64(y.lo)
+			if q64 != q || m64 != m.lo {
+				t.Errorf("(%v).QuoRem64(%v) = (%v, %v), want (%v, %v)", x, y.lo, q64, m64, q, m)
+			}
+		}
+	}
+}
+
+func TestUint128_Bits(t *testing.T) {
+	x := Uint128{hi: 0x00f0, lo: 0x0100}
+	if got, want := x.LeadingZeros(), 56; got != want {
+		t.Errorf("(%v).LeadingZeros() = %d, want %d", x, got, want)
+	}
+	if got, want := x.TrailingZeros(), 8; got != want {
+		t.Errorf("(%v).TrailingZeros() = %d, want %d", x, got, want)
+	}
+	if got, want := x.OnesCount(), 5; got != want {
+		t.Errorf("(%v).OnesCount() = %d, want %d", x, got, want)
+	}
+	var zero Uint128
+	if got, want := zero.LeadingZeros(), 128; got != want {
+		t.Errorf("(%v).LeadingZeros() = %d, want %d", zero, got, want)
+	}
+	if got, want := zero.TrailingZeros(), 128; got != want {
+		t.Errorf("(%v).TrailingZeros() = %d, want %d", zero, got, want)
+	}
+}
+
+func TestUint128_TextParse(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	for range 1000 {
+		x := testRandUint128(r)
+		for _, base := range []int{2, 8, 10, 16, 36} {
+			s := x.Text(base)
+			if want := x.Big().Text(base); s != want {
+				t.Errorf("(%v).Text(%d) = %q, want %q", x, base, s, want)
+			}
+			got, err := ParseUint128(s, base)
+			if err != nil {
+				t.Errorf("ParseUint128(%q, %d) error = %v", s, base, err)
+			}
+			if got != x {
+				t.Errorf("ParseUint128(%q, %d) = %v, want %v", s, base, got, x)
+			}
+		}
+	}
+
+	if got, err := ParseUint128("340282366920938463463374607431768211455", 10); err != nil || got != MaxUint128 {
+		t.Errorf("ParseUint128(max) = %v, %v, want %v, nil", got, err, MaxUint128)
+	}
+	if got, err := ParseUint128("0xff", 0); err != nil || got != Uint128From64(255) {
+		t.Errorf("ParseUint128(0xff, 0) = %v, %v, want 255, nil", got, err)
+	}
+	for _, s := range []string{"", "-1", "12a", "340282366920938463463374607431768211456"} {
+		if _, err := ParseUint128(s, 10); err == nil {
+			t.Errorf("ParseUint128(%q, 10) error = nil, want error", s)
+		}
+	}
+}
+
+func TestInt128_QuoRem(t *testing.T) {
+	r := rand.New(rand.NewPCG(5, 6))
+	for range 10000 {
+		x, y := Int128(testRandUint128(r)), Int128(testRandUint128(r))
+		if r.IntN(2) == 0 {
+			x = x.Neg()
+		}
+		if r.IntN(2) == 0 {
+			y = y.Neg()
+		}
+		if y.IsZero() {
+			continue
+		}
+		q, m := x.QuoRem(y)
+		wantq, wantm := new(big.Int).QuoRem(x.Big(), y.Big(), new(big.Int))
+		if x == MinInt128 && y == Int128From64(-1) {
+			// Overflows, like the built-in types.
+			wantq = MinInt128.Big()
+		}
+		if q.Big().Cmp(wantq) != 0 || m.Big().Cmp(wantm) != 0 {
+			t.Errorf("(%v).QuoRem(%v) = (%v, %v), want (%v, %v)", x, y, q, m, wantq, wantm)
+		}
+	}
+}
+
+func TestInt128_Rsh(t *testing.T) {
+	x := Int128From64(-256)
+	tests := []struct {
+		k    int
+		want Int128
+	}{
+		{k: 0, want: x},
+		{k: 4, want: Int128From64(-16)},
+		{k: 8, want: Int128From64(-1)},
+		{k: 64, want: Int128From64(-1)},
+		{k: 200, want: Int128From64(-1)},
+	}
+	for _, test := range tests {
+		if got := x.Rsh(test.k); got != test.want {
+			t.Errorf("(%v).Rsh(%d) = %v, want %v", x, test.k, got, test.want)
+		}
+	}
+}
+
+func TestInt128_TextParse(t *testing.T) {
+	tests := []struct {
+		x    Int128
+		want string
+	}{
+		{x: Int128From64(0), want: "0"},
+		{x: Int128From64(-42), want: "-42"},
+		{x: MaxInt128, want: "170141183460469231731687303715884105727"},
+		{x: MinInt128, want: "-170141183460469231731687303715884105728"},
+	}
+	for _, test := range tests {
+		if got := test.x.String(); got != test.want {
+			t.Errorf("Int128.String() = %q, want %q", got, test.want)
+		}
+		got, err := ParseInt128(test.want, 10)
+		if err != nil || got != test.x {
+			t.Errorf("ParseInt128(%q, 10) = %v, %v, want %v, nil", test.want, got, err, test.x)
+		}
+	}
+	if _, err := ParseInt128("170141183460469231731687303715884105728", 10); err == nil {
+		t.Errorf("ParseInt128(MaxInt128+1) error = nil, want error")
+	}
+}
@@ -0,0 +1,186 @@
+package exp
+
+import (
+	"encoding"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// This file implements conversions between 128-bit integers and math/big,
+// fmt, and the encoding interfaces.
+
+var (
+	_ fmt.Formatter              = Uint128{}
+	_ encoding.TextMarshaler     = Uint128{}
+	_ encoding.TextUnmarshaler   = (*Uint128)(nil)
+	_ encoding.BinaryMarshaler   = Uint128{}
+	_ encoding.BinaryUnmarshaler = (*Uint128)(nil)
+	_ json.Marshaler             = Uint128{}
+	_ json.Unmarshaler           = (*Uint128)(nil)
+
+	_ fmt.Formatter              = Int128{}
+	_ encoding.TextMarshaler     = Int128{}
+	_ encoding.TextUnmarshaler   = (*Int128)(nil)
+	_ encoding.BinaryMarshaler   = Int128{}
+	_ encoding.BinaryUnmarshaler = (*Int128)(nil)
+	_ json.Marshaler             = Int128{}
+	_ json.Unmarshaler           = (*Int128)(nil)
+)
+
+// twoTo128 is 2^128, used for converting negative values.
+var twoTo128 = new(big.Int).Lsh(big.NewInt(1), 128)
+
+// Big returns x as a new *big.Int.
+func (x Uint128) Big() *big.Int {
+	b := new(big.Int).SetUint64(x.hi)
+	b.Lsh(b, 64)
+	return b.Or(b, new(big.Int).SetUint64(x.lo))
+}
+
+// Uint128FromBig converts b into a Uint128, reporting whether b was in range
+// (0 <= b < 2^128). If b is out of range, the result is b modulo 2^128.
+func Uint128FromBig(b *big.Int) (Uint128, bool) {
+	ok := b.Sign() >= 0 && b.BitLen() <= 128
+	if !ok {
+		b = new(big.Int).Mod(b, twoTo128)
+	}
+	var buf [16]byte
+	b.FillBytes(buf[:])
+	return Uint128{
+		hi: binary.BigEndian.Uint64(buf[:8]),
+		lo: binary.BigEndian.Uint64(buf[8:]),
+	}, ok
+}
+
+// Big returns x as a new *big.Int.
+func (x Int128) Big() *big.Int {
+	b := Uint128(x).Big()
+	if x.Sign() < 0 {
+		b.Sub(b, twoTo128)
+	}
+	return b
+}
+
+// Int128FromBig converts b into an Int128, reporting whether b was in range
+// (-2^127 <= b < 2^127). If b is out of range, the result is b wrapped
+// modulo 2^128 into the range, like a conversion between built-in integer
+// types.
+func Int128FromBig(b *big.Int) (Int128, bool) {
+	u, _ := Uint128FromBig(b)
+	x := Int128(u)
+	return x, x.Big().Cmp(b) == 0
+}
+
+// Format implements fmt.Formatter. It supports the same verbs and flags as
+// *big.Int (%b, %o, %O, %d, %x, %X, %s and %v).
+func (x Uint128) Format(s fmt.State, verb rune) { x.Big().Format(s, verb) }
+
+// Format implements fmt.Formatter. It supports the same verbs and flags as
+// *big.Int (%b, %o, %O, %d, %x, %X, %s and %v).
+func (x Int128) Format(s fmt.State, verb rune) { x.Big().Format(s, verb) }
+
+// MarshalText implements encoding.TextMarshaler. The text is in base 10.
+func (x Uint128) MarshalText() ([]byte, error) { return x.appendText(nil, 10), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler. The text must be in
+// base 10, as produced by MarshalText.
+func (x *Uint128) UnmarshalText(text []byte) error {
+	y, err := ParseUint128(string(text), 10)
+	if err != nil {
+		return err
+	}
+	*x = y
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler. The text is in base 10.
+func (x Int128) MarshalText() ([]byte, error) { return []byte(x.Text(10)), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler. The text must be in
+// base 10, as produced by MarshalText.
+func (x *Int128) UnmarshalText(text []byte) error {
+	y, err := ParseInt128(string(text), 10)
+	if err != nil {
+		return err
+	}
+	*x = y
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The encoding is 16 bytes,
+// big-endian.
+func (x Uint128) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 16)
+	binary.BigEndian.PutUint64(b[:8], x.hi)
+	binary.BigEndian.PutUint64(b[8:], x.lo)
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It expects exactly
+// 16 bytes, big-endian.
+func (x *Uint128) UnmarshalBinary(data []byte) error {
+	if len(data) != 16 {
+		return fmt.Errorf("Uint128.UnmarshalBinary: got %d bytes, want 16", len(data))
+	}
+	x.hi = binary.BigEndian.Uint64(data[:8])
+	x.lo = binary.BigEndian.Uint64(data[8:])
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The encoding is 16 bytes,
+// big-endian, two's complement.
+func (x Int128) MarshalBinary() ([]byte, error) { return Uint128(x).MarshalBinary() }
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It expects exactly
+// 16 bytes, big-endian, two's complement.
+func (x *Int128) UnmarshalBinary(data []byte) error {
+	if len(data) != 16 {
+		return fmt.Errorf("Int128.UnmarshalBinary: got %d bytes, want 16", len(data))
+	}
+	return (*Uint128)(x).UnmarshalBinary(data)
+}
+
+// MarshalJSON implements json.Marshaler. Like *big.Int, the value is encoded
+// as a JSON number (which some JSON decoders cannot represent exactly).
+func (x Uint128) MarshalJSON() ([]byte, error) { return x.MarshalText() }
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts a JSON number or a
+// JSON string containing a number. As is conventional, null is a no-op.
+func (x *Uint128) UnmarshalJSON(data []byte) error {
+	text, err := jsonIntText(data)
+	if err != nil || text == nil {
+		return err
+	}
+	return x.UnmarshalText(text)
+}
+
+// MarshalJSON implements json.Marshaler. Like *big.Int, the value is encoded
+// as a JSON number (which some JSON decoders cannot represent exactly).
+func (x Int128) MarshalJSON() ([]byte, error) { return x.MarshalText() }
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts a JSON number or a
+// JSON string containing a number. As is conventional, null is a no-op.
+func (x *Int128) UnmarshalJSON(data []byte) error {
+	text, err := jsonIntText(data)
+	if err != nil || text == nil {
+		return err
+	}
+	return x.UnmarshalText(text)
+}
+
+// jsonIntText strips quotes from a JSON string, if present. It returns nil
+// text for a JSON null.
+func jsonIntText(data []byte) ([]byte, error) {
+	switch {
+	case string(data) == "null":
+		return nil, nil
+	case len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"':
+		return data[1 : len(data)-1], nil
+	case len(data) > 0 && data[0] == '"':
+		return nil, errors.New("unterminated JSON string")
+	}
+	return data, nil
+}
@@ -0,0 +1,122 @@
+package exp
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+func TestUint128_BigRoundTrip(t *testing.T) {
+	tests := []Uint128{
+		{},
+		{lo: 1},
+		{hi: 1},
+		{hi: 0x0123456789abcdef, lo: 0xfedcba9876543210},
+		MaxUint128,
+	}
+	for _, x := range tests {
+		got, ok := Uint128FromBig(x.Big())
+		if !ok || got != x {
+			t.Errorf("Uint128FromBig((%v).Big()) = %v, %t, want %v, true", x, got, ok, x)
+		}
+	}
+
+	tooBig := new(big.Int).Lsh(big.NewInt(1), 128)
+	if got, ok := Uint128FromBig(tooBig); ok || !got.IsZero() {
+		t.Errorf("Uint128FromBig(2^128) = %v, %t, want 0, false", got, ok)
+	}
+	if got, ok := Uint128FromBig(big.NewInt(-1)); ok || got != MaxUint128 {
+		t.Errorf("Uint128FromBig(-1) = %v, %t, want %v, false", got, ok, MaxUint128)
+	}
+}
+
+func TestInt128_BigRoundTrip(t *testing.T) {
+	tests := []Int128{
+		{},
+		Int128From64(1),
+		Int128From64(-1),
+		MaxInt128,
+		MinInt128,
+	}
+	for _, x := range tests {
+		got, ok := Int128FromBig(x.Big())
+		if !ok || got != x {
+			t.Errorf("Int128FromBig((%v).Big()) = %v, %t, want %v, true", x, got, ok, x)
+		}
+	}
+
+	tooBig := new(big.Int).Lsh(big.NewInt(1), 127)
+	if got, ok := Int128FromBig(tooBig); ok || got != MinInt128 {
+		t.Errorf("Int128FromBig(2^127) = %v, %t, want %v, false", got, ok, MinInt128)
+	}
+}
+
+func TestUint128_Format(t *testing.T) {
+	x := NewUint128(1, 0xff)
+	tests := []struct {
+		format, want string
+	}{
+		{format: "%v", want: "18446744073709551871"},
+		{format: "%d", want: "18446744073709551871"},
+		{format: "%x", want: "100000000000000ff"},
+		{format: "%#X", want: "0X100000000000000FF"},
+		{format: "%o", want: "2000000000000000000377"},
+		{format: "%b", want: "1" + fmt.Sprintf("%064b", 0xff)},
+		{format: "%25d", want: "     18446744073709551871"},
+	}
+	for _, test := range tests {
+		if got := fmt.Sprintf(test.format, x); got != test.want {
+			t.Errorf("Sprintf(%q, %v) = %q, want %q", test.format, x, got, test.want)
+		}
+	}
+
+	if got, want := fmt.Sprintf("%d", Int128From64(-42)), "-42"; got != want {
+		t.Errorf("Sprintf(%%d, -42) = %q, want %q", got, want)
+	}
+}
+
+func TestUint128_Marshal(t *testing.T) {
+	type record struct {
+		U Uint128
+		I Int128
+	}
+	in := record{U: MaxUint128, I: MinInt128}
+	b, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("json.Marshal(%v) error = %v", in, err)
+	}
+	if got, want := string(b), `{"U":340282366920938463463374607431768211455,"I":-170141183460469231731687303715884105728}`; got != want {
+		t.Errorf("json.Marshal(%v) = %s, want %s", in, got, want)
+	}
+	var out record
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatalf("json.Unmarshal(%s) error = %v", b, err)
+	}
+	if out != in {
+		t.Errorf("json.Unmarshal(%s) = %v, want %v", b, out, in)
+	}
+	if err := json.Unmarshal([]byte(`{"U":"010","I":"-7"}`), &out); err != nil {
+		t.Fatalf("json.Unmarshal(quoted) error = %v", err)
+	}
+	if want := (record{U: Uint128From64(10), I: Int128From64(-7)}); out != want {
+		t.Errorf("json.Unmarshal(quoted) = %v, want %v", out, want)
+	}
+	for _, bad := range []string{`{"U":"0x10"}`, `{"I":"-0b1"}`, `{"U":"1_000"}`} {
+		if err := json.Unmarshal([]byte(bad), &out); err == nil {
+			t.Errorf("json.Unmarshal(%s) error = nil, want error", bad)
+		}
+	}
+
+	bin, err := in.I.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() error = %v", err)
+	}
+	var i Int128
+	if err := i.UnmarshalBinary(bin); err != nil || i != in.I {
+		t.Errorf("UnmarshalBinary(%x) = %v, %v, want %v, nil", bin, i, err, in.I)
+	}
+	if err := i.UnmarshalBinary(bin[1:]); err == nil {
+		t.Errorf("UnmarshalBinary(15 bytes) error = nil, want error")
+	}
+}
@@ -0,0 +1,138 @@
+package exp
+
+import "math/bits"
+
+// This file implements overflow-checked ("Overflow") and saturating ("Sat")
+// arithmetic for 128-bit integers, mirroring the functions of the same names
+// in the algo package. The Overflow methods return the wrapped result together
+// with ok == false if overflow occurred. The Sat methods clamp the result to
+// the range of the type instead of wrapping.
+
+// AddOverflow returns x + y, and reports whether the sum did not overflow.
+func (x Uint128) AddOverflow(y Uint128) (z Uint128, ok bool) {
+	var c uint64
+	z.lo, c = bits.Add64(x.lo, y.lo, 0)
+	z.hi, c = bits.Add64(x.hi, y.hi, c)
+	return z, c == 0
+}
+
+// SubOverflow returns x - y, and reports whether the difference did not
+// overflow.
+func (x Uint128) SubOverflow(y Uint128) (z Uint128, ok bool) {
+	var b uint64
+	z.lo, b = bits.Sub64(x.lo, y.lo, 0)
+	z.hi, b = bits.Sub64(x.hi, y.hi, b)
+	return z, b == 0
+}
+
+// MulOverflow returns x * y, and reports whether the product did not
+// overflow.
+func (x Uint128) MulOverflow(y Uint128) (z Uint128, ok bool) {
+	z = x.Mul(y)
+	if x.hi != 0 && y.hi != 0 {
+		return z, false
+	}
+	// At most one of the cross terms is non-zero.
+	h1, l1 := bits.Mul64(x.hi, y.lo)
+	h2, l2 := bits.Mul64(x.lo, y.hi)
+	if h1 != 0 || h2 != 0 {
+		return z, false
+	}
+	h, _ := bits.Mul64(x.lo, y.lo)
+	_, c := bits.Add64(h, l1|l2, 0)
+	return z, c == 0
+}
+
+// AddSat returns x + y, or MaxUint128 if the sum overflows.
+func (x Uint128) AddSat(y Uint128) Uint128 {
+	if z, ok := x.AddOverflow(y); ok {
+		return z
+	}
+	return MaxUint128
+}
+
+// SubSat returns x - y, or 0 if the difference overflows.
+func (x Uint128) SubSat(y Uint128) Uint128 {
+	if z, ok := x.SubOverflow(y); ok {
+		return z
+	}
+	return Uint128{}
+}
+
+// MulSat returns x * y, or MaxUint128 if the product overflows.
+func (x Uint128) MulSat(y Uint128) Uint128 {
+	if z, ok := x.MulOverflow(y); ok {
+		return z
+	}
+	return MaxUint128
+}
+
+// AddOverflow returns x + y, and reports whether the sum did not overflow.
+func (x Int128) AddOverflow(y Int128) (Int128, bool) {
+	z := x.Add(y)
+	if y.Sign() >= 0 {
+		return z, z.Cmp(x) >= 0
+	}
+	return z, z.Cmp(x) < 0
+}
+
+// SubOverflow returns x - y, and reports whether the difference did not
+// overflow.
+func (x Int128) SubOverflow(y Int128) (Int128, bool) {
+	z := x.Sub(y)
+	if y.Sign() >= 0 {
+		return z, z.Cmp(x) <= 0
+	}
+	return z, z.Cmp(x) > 0
+}
+
+// MulOverflow returns x * y, and reports whether the product did not
+// overflow.
+func (x Int128) MulOverflow(y Int128) (Int128, bool) {
+	z := x.Mul(y)
+	p, ok := x.Abs().MulOverflow(y.Abs())
+	if !ok {
+		return z, false
+	}
+	if x.Sign()*y.Sign() < 0 {
+		return z, p.Cmp(Uint128(MinInt128)) <= 0
+	}
+	return z, p.Cmp(Uint128(MaxInt128)) <= 0
+}
+
+// NegOverflow returns -x, and reports whether the negation did not overflow
+// (it overflows only for MinInt128).
+func (x Int128) NegOverflow() (Int128, bool) { return x.Neg(), x != MinInt128 }
+
+// AddSat returns x + y, clamped to the range [MinInt128, MaxInt128].
+func (x Int128) AddSat(y Int128) Int128 {
+	if z, ok := x.AddOverflow(y); ok {
+		return z
+	}
+	if y.Sign() > 0 {
+		return MaxInt128
+	}
+	return MinInt128
+}
+
+// SubSat returns x - y, clamped to the range [MinInt128, MaxInt128].
+func (x Int128) SubSat(y Int128) Int128 {
+	if z, ok := x.SubOverflow(y); ok {
+		return z
+	}
+	if y.Sign() > 0 {
+		return MinInt128
+	}
+	return MaxInt128
+}
+
+// MulSat returns x * y, clamped to the range [MinInt128, MaxInt128].
+func (x Int128) MulSat(y Int128) Int128 {
+	if z, ok := x.MulOverflow(y); ok {
+		return z
+	}
+	if x.Sign()*y.Sign() < 0 {
+		return MinInt128
+	}
+	return MaxInt128
+}
@@ -0,0 +1,88 @@
+package exp
+
+import (
+	"math/big"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestUint128_Overflow(t *testing.T) {
+	r := rand.New(rand.NewPCG(7, 8))
+	for range 10000 {
+		x, y := testRandUint128(r), testRandUint128(r)
+		bx, by := x.Big(), y.Big()
+
+		checks := []struct {
+			name string
+			got  Uint128
+			ok   bool
+			sat  Uint128
+			want *big.Int
+		}{
+			{"Add", x.Add(y), true, x.AddSat(y), new(big.Int).Add(bx, by)},
+			{"Sub", x.Sub(y), true, x.SubSat(y), new(big.Int).Sub(bx, by)},
+			{"Mul", x.Mul(y), true, x.MulSat(y), new(big.Int).Mul(bx, by)},
+		}
+		checks[0].got, checks[0].ok = x.AddOverflow(y)
+		checks[1].got, checks[1].ok = x.SubOverflow(y)
+		checks[2].got, checks[2].ok = x.MulOverflow(y)
+
+		for _, c := range checks {
+			_, inRange := Uint128FromBig(c.want)
+			if c.ok != inRange {
+				t.Errorf("(%v).%sOverflow(%v) ok = %t, want %t", x, c.name, y, c.ok, inRange)
+			}
+			wantSat := c.got
+			switch {
+			case c.want.Sign() < 0:
+				wantSat = Uint128{}
+			case !inRange:
+				wantSat = MaxUint128
+			}
+			if c.sat != wantSat {
+				t.Errorf("(%v).%sSat(%v) = %v, want %v", x, c.name, y, c.sat, wantSat)
+			}
+		}
+	}
+}
+
+func TestInt128_Overflow(t *testing.T) {
+	r := rand.New(rand.NewPCG(9, 10))
+	for range 10000 {
+		x, y := Int128(testRandUint128(r)), Int128(testRandUint128(r))
+		bx, by := x.Big(), y.Big()
+
+		checks := []struct {
+			name string
+			got  Int128
+			ok   bool
+			sat  Int128
+			want *big.Int
+		}{
+			{"Add", Int128{}, false, x.AddSat(y), new(big.Int).Add(bx, by)},
+			{"Sub", Int128{}, false, x.SubSat(y), new(big.Int).Sub(bx, by)},
+			{"Mul", Int128{}, false, x.MulSat(y), new(big.Int).Mul(bx, by)},
+		}
+		checks[0].got, checks[0].ok = x.AddOverflow(y)
+		checks[1].got, checks[1].ok = x.SubOverflow(y)
+		checks[2].got, checks[2].ok = x.MulOverflow(y)
+
+		for _, c := range checks {
+			_, inRange := Int128FromBig(c.want)
+			if c.ok != inRange {
+				t.Errorf("(%v).%sOverflow(%v) ok = %t, want %t", x, c.name, y, c.ok, inRange)
+			}
+			wantSat := c.got
+			switch {
+			case inRange:
+			case c.want.Sign() < 0:
+				wantSat = MinInt128
+			default:
+				wantSat = MaxInt128
+			}
+			if c.sat != wantSat {
+				t.Errorf("(%v).%sSat(%v) = %v, want %v", x, c.name, y, c.sat, wantSat)
+			}
+		}
+	}
+}
@@ -20,14 +20,15 @@
 package exp // import "drjosh.dev/exp"
 
 import (
-	"bufio"
 	"fmt"
 	"io"
+	"io/fs"
 	"iter"
 	"log"
 	"os"
 	"strconv"
 	"strings"
+	"unicode"
 
 	"drjosh.dev/exp/grid"
 )
@@ -114,48 +115,43 @@ func MustCut(s, sep string) (before, after string) {
 }
 
 // MustForEachLineIn calls cb with each line in the file.
-// It uses a bufio.Scanner internally, which can fail on longer lines.
+// It uses a bufio.Scanner internally, which by default fails on lines longer
+// than 64 KiB (see WithMaxTokenSize). The options can also change how the
+// input is split into "lines" (see WithSplit).
 // If an error is encountered, it calls log.Fatal.
 // This is a helper intended for very simple programs (e.g. Advent of Code)
 // and is not recommended for production code, particularly because the
 // logged message may be somewhat unhelpful.
-func MustForEachLineIn(path string, cb func(line string)) {
-	f, err := os.Open(path)
-	if err != nil {
-		log.Fatalf("MustForEachLineIn: opening file: %v", err)
-	}
-	defer f.Close()
-	sc := bufio.NewScanner(f)
-	for sc.Scan() {
-		cb(sc.Text())
-	}
-	if err := sc.Err(); err != nil {
-		log.Fatalf("MustForEachLineIn: scanner: %v", err)
+func MustForEachLineIn(path string, cb func(line string), opts ...ScanOption) {
+	for line := range LinesInFile(path, opts...) {
+		cb(line)
 	}
 }
 
 // LinesInFile yields each line in the file.
-// It uses a bufio.Scanner internally, which can fail on longer lines.
+// It uses a bufio.Scanner internally, which by default fails on lines longer
+// than 64 KiB (see WithMaxTokenSize). The options can also change how the
+// input is split into "lines" (see WithSplit).
 // If an error is encountered, it calls log.Fatal.
 // This is a helper intended for very simple programs (e.g. Advent of Code)
 // and is not recommended for production code, particularly because the
-// logged message may be somewhat unhelpful.
-func LinesInFile(path string) iter.Seq[string] {
+// logged message may be somewhat unhelpful. Use Lines for a version that
+// reports errors.
+func LinesInFile(path string, opts ...ScanOption) iter.Seq[string] {
 	return func(yield func(string) bool) {
 		f, err := os.Open(path)
 		if err != nil {
-			log.Fatalf("MustForEachLineIn: opening file: %v", err)
+			log.Fatalf("LinesInFile: opening file: %v", err)
 		}
 		defer f.Close()
-		sc := bufio.NewScanner(f)
-		for sc.Scan() {
-			if !yield(sc.Text()) {
+		for line, err := range Lines(f, opts...) {
+			if err != nil {
+				log.Fatalf("LinesInFile: %s: %v", path, err)
+			}
+			if !yield(line) {
 				return
 			}
 		}
-		if err := sc.Err(); err != nil {
-			log.Fatalf("MustForEachLineIn: scanner: %v", err)
-		}
 	}
 }
 
@@ -165,15 +161,12 @@ func LinesInFile(path string) iter.Seq[string] {
 // If an error is encountered, it calls log.Fatal.
 // This is a helper intended for very simple programs (e.g. Advent of Code)
 // and is not recommended for production code, particularly because the
-// logged message may be somewhat unhelpful.
+// logged message may be somewhat unhelpful. Use ReadLines for a version that
+// returns errors.
 func MustReadLines(path string) []string {
-	b, err := os.ReadFile(path)
+	lines, err := ReadFile(path, ReadLines)
 	if err != nil {
-		log.Fatalf("MustReadLines: opening file: %v", err)
-	}
-	lines := strings.Split(string(b), "\n")
-	if n1 := len(lines) - 1; lines[n1] == "" {
-		return lines[:n1]
+		log.Fatalf("MustReadLines: %v", err)
 	}
 	return lines
 }
@@ -184,15 +177,14 @@ func MustReadLines(path string) []string {
 // If an error is encountered, it calls log.Fatal.
 // This is a helper intended for very simple programs (e.g. Advent of Code)
 // and is not recommended for production code, particularly because the
-// logged message may be somewhat unhelpful.
+// logged message may be somewhat unhelpful. Use ReadDelimited for a version
+// that returns errors.
 func MustReadDelimited(path, delim string) []string {
-	b, err := os.ReadFile(path)
+	parts, err := ReadFile(path, func(r io.Reader) ([]string, error) {
+		return ReadDelimited(r, delim)
+	})
 	if err != nil {
-		log.Fatalf("MustReadLines: opening file: %v", err)
-	}
-	parts := strings.Split(string(b), delim)
-	for i, l := range parts {
-		parts[i] = strings.TrimSpace(l)
+		log.Fatalf("MustReadDelimited: %v", err)
 	}
 	return parts
 }
@@ -203,31 +195,167 @@ func MustReadDelimited(path, delim string) []string {
 // If an error is encountered, it calls log.Fatal.
 // This is a helper intended for very simple programs (e.g. Advent of Code)
 // and is not recommended for production code, particularly because the
-// logged message may be somewhat unhelpful.
+// logged message may be somewhat unhelpful. Use ReadInts for a version that
+// returns errors.
 func MustReadInts(path, delim string) []int {
-	b, err := os.ReadFile(path)
+	ints, err := ReadFile(path, func(r io.Reader) ([]int, error) {
+		return ReadInts(r, delim)
+	})
 	if err != nil {
-		log.Fatalf("MustReadInts: opening file: %v", err)
+		log.Fatalf("MustReadInts: %v", err)
+	}
+	return ints
+}
+
+// MustReadByteGrid reads the entire file into memory and returns the contents
+// in the form of a dense byte grid.
+// If an error is encountered, it calls log.Fatal. Use ReadByteGrid for a
+// version that returns errors.
+func MustReadByteGrid(path string) grid.Dense[byte] {
+	g, err := ReadFile(path, ReadByteGrid)
+	if err != nil {
+		log.Fatalf("MustReadByteGrid: %v", err)
+	}
+	return g
+}
+
+// LineError records an error that occurred while reading a particular line of
+// input.
+type LineError struct {
+	Line int // 1-based line number
+	Err  error
+}
+
+func (e *LineError) Error() string { return fmt.Sprintf("line %d: %v", e.Line, e.Err) }
+func (e *LineError) Unwrap() error { return e.Err }
+
+// ReadFile opens the file at path, and passes it to read. Errors from read are
+// annotated with the path.
+func ReadFile[T any](path string, read func(io.Reader) (T, error)) (T, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	defer f.Close()
+	t, err := read(f)
+	if err != nil {
+		return t, fmt.Errorf("%s: %w", path, err)
+	}
+	return t, nil
+}
+
+// ReadFS opens the named file within fsys (for example, an embed.FS or
+// os.DirFS), and passes it to read. Errors from read are annotated with the
+// name.
+func ReadFS[T any](fsys fs.FS, name string, read func(io.Reader) (T, error)) (T, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	defer f.Close()
+	t, err := read(f)
+	if err != nil {
+		return t, fmt.Errorf("%s: %w", name, err)
+	}
+	return t, nil
+}
+
+// Lines yields each line read from r, with a nil error.
+// It uses a bufio.Scanner internally, which by default fails on lines longer
+// than 64 KiB (see WithMaxTokenSize). The options can also change how the
+// input is split into "lines" (see WithSplit). If reading fails, the last item
+// yielded is a *LineError (where the line number counts tokens).
+func Lines(r io.Reader, opts ...ScanOption) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		sc, err := NewScanner(r, opts...)
+		if err != nil {
+			yield("", &LineError{Line: 1, Err: err})
+			return
+		}
+		n := 0
+		for sc.Scan() {
+			n++
+			if !yield(sc.Text(), nil) {
+				return
+			}
+		}
+		if err := sc.Err(); err != nil {
+			yield("", &LineError{Line: n + 1, Err: err})
+		}
+	}
+}
+
+// ReadLines reads all of r into memory and returns a slice containing each
+// line of text (essentially, strings.Split(contents, "\n"), but ignoring the
+// final element if it is empty).
+func ReadLines(r io.Reader) ([]string, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(string(b), "\n")
+	if n1 := len(lines) - 1; lines[n1] == "" {
+		return lines[:n1], nil
+	}
+	return lines, nil
+}
+
+// ReadDelimited reads all of r into memory, splits the contents by a
+// delimiter, trims leading and trailing spaces from each component, and
+// returns the results as a slice.
+func ReadDelimited(r io.Reader, delim string) ([]string, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	parts := strings.Split(string(b), delim)
+	for i, l := range parts {
+		parts[i] = strings.TrimSpace(l)
+	}
+	return parts, nil
+}
+
+// ReadInts reads all of r into memory, splits the contents by the delimiter,
+// parses each component as a decimal integer, and returns them as a slice.
+// Parsing errors are reported as a *LineError.
+func ReadInts(r io.Reader, delim string) ([]int, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
 	}
 	parts := strings.Split(string(b), delim)
 	if n1 := len(parts) - 1; parts[n1] == "" {
 		parts = parts[:n1]
 	}
 	out := make([]int, len(parts))
+	line := 1
 	for i, s := range parts {
-		n, err := strconv.Atoi(strings.TrimSpace(s))
+		t := strings.TrimSpace(s)
+		n, err := strconv.Atoi(t)
 		if err != nil {
-			log.Fatalf("MustReadInts: parsing part %d %q: %v", i, s, err)
+			// Report the line containing the start of the number (or the
+			// garbage where one was expected). If the part is blank, that
+			// is the line where the part ends.
+			lead := len(s) - len(strings.TrimLeftFunc(s, unicode.IsSpace))
+			line += strings.Count(s[:lead], "\n")
+			return nil, &LineError{Line: line, Err: fmt.Errorf("part %d: %w", i, err)}
 		}
 		out[i] = n
+		line += strings.Count(s, "\n") + strings.Count(delim, "\n")
 	}
-	return out
+	return out, nil
 }
 
-// MustReadByteGrid reads the entire file into memory and returns the contents
-// in the form of a dense byte grid.
-func MustReadByteGrid(path string) grid.Dense[byte] {
-	return grid.BytesFromStrings(MustReadLines(path))
+// ReadByteGrid reads all of r into memory and returns the contents in the
+// form of a dense byte grid.
+func ReadByteGrid(r io.Reader) (grid.Dense[byte], error) {
+	lines, err := ReadLines(r)
+	if err != nil {
+		return nil, err
+	}
+	return grid.BytesFromStrings(lines), nil
 }
 
 // Fmatchf wraps fmt.Fscanf, reporting whether input was scanned successfully.
@@ -0,0 +1,115 @@
+package exp
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"drjosh.dev/exp/grid"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReadLines(t *testing.T) {
+	got, err := ReadLines(strings.NewReader("abc\ndef\n\nghi\n"))
+	if err != nil {
+		t.Fatalf("ReadLines() error = %v", err)
+	}
+	want := []string{"abc", "def", "", "ghi"}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("ReadLines() diff (-got +want):\n%s", diff)
+	}
+}
+
+func TestReadInts(t *testing.T) {
+	got, err := ReadInts(strings.NewReader("1, 2,\n 3,-4\n"), ",")
+	if err != nil {
+		t.Fatalf("ReadInts() error = %v", err)
+	}
+	if want := []int{1, 2, 3, -4}; !slices.Equal(got, want) {
+		t.Errorf("ReadInts() = %v, want %v", got, want)
+	}
+
+	_, err = ReadInts(strings.NewReader("1\n2\n\nx\n5\n"), "\n")
+	var le *LineError
+	if !errors.As(err, &le) {
+		t.Fatalf("ReadInts() error = %v, want *LineError", err)
+	}
+	if got, want := le.Line, 3; got != want {
+		t.Errorf("LineError.Line = %d, want %d", got, want)
+	}
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("ReadInts() error = %v, want %v", err, strconv.ErrSyntax)
+	}
+
+	// Blank parts spanning lines.
+	for _, test := range []struct {
+		input string
+		line  int
+	}{
+		{input: "1,\n\n,2", line: 3},
+		{input: "1,2,\n \n", line: 3},
+		{input: "1,\n\n x,2", line: 3},
+	} {
+		_, err := ReadInts(strings.NewReader(test.input), ",")
+		if !errors.As(err, &le) {
+			t.Fatalf("ReadInts(%q) error = %v, want *LineError", test.input, err)
+		}
+		if le.Line != test.line {
+			t.Errorf("ReadInts(%q): LineError.Line = %d, want %d", test.input, le.Line, test.line)
+		}
+	}
+}
+
+func TestReadFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"grid.txt": {Data: []byte("#.\n.#\n")},
+		"ints.txt": {Data: []byte("1,2,bad")},
+	}
+
+	g, err := ReadFS(fsys, "grid.txt", ReadByteGrid)
+	if err != nil {
+		t.Fatalf("ReadFS(grid.txt) error = %v", err)
+	}
+	want := grid.Dense[byte]{[]byte("#."), []byte(".#")}
+	if diff := cmp.Diff(g, want); diff != "" {
+		t.Errorf("ReadFS(grid.txt) diff (-got +want):\n%s", diff)
+	}
+
+	_, err = ReadFS(fsys, "ints.txt", func(r io.Reader) ([]int, error) {
+		return ReadInts(r, ",")
+	})
+	if err == nil || !strings.HasPrefix(err.Error(), "ints.txt: line 1: ") {
+		t.Errorf("ReadFS(ints.txt) error = %v, want error starting with %q", err, "ints.txt: line 1: ")
+	}
+
+	if _, err := ReadFS(fsys, "missing.txt", ReadLines); err == nil {
+		t.Errorf("ReadFS(missing.txt) error = nil, want error")
+	}
+}
+
+func TestLines(t *testing.T) {
+	var got []string
+	for line, err := range Lines(strings.NewReader("a\nb\nc")) {
+		if err != nil {
+			t.Fatalf("Lines() error = %v", err)
+		}
+		got = append(got, line)
+	}
+	if want := []string{"a", "b", "c"}; !slices.Equal(got, want) {
+		t.Errorf("Lines() = %v, want %v", got, want)
+	}
+
+	long := strings.Repeat("x", 100000)
+	var lastErr error
+	for _, err := range Lines(strings.NewReader("ok\n" + long)) {
+		lastErr = err
+	}
+	var le *LineError
+	if !errors.As(lastErr, &le) || le.Line != 2 {
+		t.Errorf("Lines(long line) error = %v, want *LineError at line 2", lastErr)
+	}
+}
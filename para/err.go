@@ -0,0 +1,120 @@
+package para
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+)
+
+// PanicError is returned by DoErr and MapErr when f panics.
+type PanicError struct {
+	Value any    // the value passed to panic
+	Stack []byte // the stack trace of the panicking goroutine
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v\n\n%s", e.Value, e.Stack)
+}
+
+// Unwrap returns the value passed to panic, if it is an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// DoErr calls f with each element of in.
+// It does this in parallel, using up to workers goroutines (or GOMAXPROCS
+// goroutines, if workers <= 0). The context passed to f is cancelled when
+// f returns an error or panics, or when ctx is cancelled, and then no more
+// calls to f are started. Panics in f are recovered and returned as a
+// *PanicError.
+// DoErr returns the first error returned by f, or ctx.Err() if ctx was
+// cancelled before all elements were processed.
+func DoErr[S ~[]E, E any](ctx context.Context, in S, workers int, f func(context.Context, E) error) error {
+	return run(ctx, len(in), workers, func(ctx context.Context, i int) error {
+		return f(ctx, in[i])
+	})
+}
+
+// MapErr calls f with each element of in, to build the output slice.
+// Like Map, out[i] is the result of f(in[i]) regardless of the order in which
+// the calls happen. It does the mapping in parallel, with the same
+// concurrency, cancellation, and error handling as DoErr. If an error occurs,
+// the output slice is nil.
+func MapErr[S ~[]X, X, Y any](ctx context.Context, in S, workers int, f func(context.Context, X) (Y, error)) ([]Y, error) {
+	out := make([]Y, len(in))
+	err := run(ctx, len(in), workers, func(ctx context.Context, i int) error {
+		y, err := f(ctx, in[i])
+		out[i] = y
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// run calls f(ctx, i) for each i in [0, n), using up to workers goroutines.
+// Each goroutine takes the next index when it is ready.
+func run(ctx context.Context, n, workers int, f func(context.Context, int) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, n)
+
+	wctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		next, done atomic.Int64
+		wg         sync.WaitGroup
+		once       sync.Once
+		firstErr   error
+	)
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for wctx.Err() == nil {
+				i := int(next.Add(1) - 1)
+				if i >= n {
+					return
+				}
+				if err := call(func() error { return f(wctx, i) }); err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					return
+				}
+				done.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	if int(done.Load()) < n {
+		return ctx.Err()
+	}
+	return nil
+}
+
+// call calls f, converting a panic into a *PanicError.
+func call(f func() error) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = &PanicError{Value: v, Stack: debug.Stack()}
+		}
+	}()
+	return f()
+}
@@ -0,0 +1,128 @@
+package para
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"drjosh.dev/exp/algo"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMapErr(t *testing.T) {
+	for N := 0; N < 300; N += 7 {
+		in := make([]int, N)
+		for i := range in {
+			in[i] = i
+		}
+		for _, workers := range []int{0, 1, 3} {
+			got, err := MapErr(context.Background(), in, workers, func(_ context.Context, x int) (string, error) {
+				return strconv.Itoa(x), nil
+			})
+			if err != nil {
+				t.Fatalf("MapErr(len %d, %d workers) error = %v", N, workers, err)
+			}
+			if diff := cmp.Diff(got, algo.Map(in, strconv.Itoa)); diff != "" {
+				t.Errorf("MapErr(len %d, %d workers) diff (-got +want):\n%s", N, workers, diff)
+			}
+		}
+	}
+}
+
+func TestDoErrWorkerLimit(t *testing.T) {
+	var running, peak atomic.Int32
+	err := DoErr(context.Background(), make([]int, 100), 3, func(context.Context, int) error {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		running.Add(-1)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("DoErr() error = %v", err)
+	}
+	if got := peak.Load(); got > 3 {
+		t.Errorf("peak concurrency = %d, want at most 3", got)
+	}
+}
+
+func TestDoErrFirstError(t *testing.T) {
+	errBoom := errors.New("boom")
+	in := make([]int, 100)
+	for i := range in {
+		in[i] = i
+	}
+
+	// With one worker, the calls happen in order and stop at the error.
+	var calls atomic.Int32
+	err := DoErr(context.Background(), in, 1, func(_ context.Context, x int) error {
+		calls.Add(1)
+		if x == 10 {
+			return errBoom
+		}
+		return nil
+	})
+	if !errors.Is(err, errBoom) {
+		t.Errorf("DoErr() error = %v, want %v", err, errBoom)
+	}
+	if got, want := calls.Load(), int32(11); got != want {
+		t.Errorf("DoErr() made %d calls, want %d", got, want)
+	}
+
+	// Other workers see their context cancelled.
+	got, err := MapErr(context.Background(), in, 4, func(ctx context.Context, x int) (int, error) {
+		if x == 0 {
+			return 0, errBoom
+		}
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	if !errors.Is(err, errBoom) || got != nil {
+		t.Errorf("MapErr() = %v, %v, want nil, %v", got, err, errBoom)
+	}
+}
+
+func TestDoErrPanic(t *testing.T) {
+	err := DoErr(context.Background(), []int{1, 2, 3}, 0, func(_ context.Context, x int) error {
+		if x == 2 {
+			panic(errors.ErrUnsupported)
+		}
+		return nil
+	})
+	var pe *PanicError
+	if !errors.As(err, &pe) {
+		t.Fatalf("DoErr() error = %v, want *PanicError", err)
+	}
+	if !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("DoErr() error = %v, want %v", err, errors.ErrUnsupported)
+	}
+	if len(pe.Stack) == 0 {
+		t.Errorf("PanicError.Stack is empty")
+	}
+}
+
+func TestDoErrContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls atomic.Int32
+	err := DoErr(ctx, make([]int, 100), 1, func(context.Context, int) error {
+		if calls.Add(1) == 5 {
+			cancel()
+		}
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("DoErr() error = %v, want %v", err, context.Canceled)
+	}
+	if got, want := calls.Load(), int32(5); got != want {
+		t.Errorf("DoErr() made %d calls, want %d", got, want)
+	}
+	if err := DoErr(ctx, []int{}, 0, func(context.Context, int) error { return nil }); !errors.Is(err, context.Canceled) {
+		t.Errorf("DoErr(cancelled ctx) error = %v, want %v", err, context.Canceled)
+	}
+}
@@ -4,95 +4,210 @@ package para
 import (
 	"runtime"
 	"sync"
+	"sync/atomic"
 
 	"drjosh.dev/exp/algo"
 )
 
 // Do calls f with each element of in.
 // It does this in parallel, using up to GOMAXPROCS goroutines.
+// See DoErr for a version supporting errors and cancellation.
 func Do[S ~[]E, E any](in S, f func(E)) {
-	if len(in) == 0 {
-		return
-	}
-
-	var wg sync.WaitGroup
-
-	for _, chunk := range Divvy(in, runtime.GOMAXPROCS(0)) {
-		wg.Add(1)
-		chunk := chunk
-
-		go func() {
-			defer wg.Done()
-
-			for _, e := range chunk {
-				f(e)
-			}
-		}()
-	}
-
-	wg.Wait()
+	forChunks(len(in), func(_, _, lo, hi int) {
+		for _, e := range in[lo:hi] {
+			f(e)
+		}
+	})
 }
 
 // Map calls f with each element of in, to build the output slice.
 // It does the mapping in parallel, using up to GOMAXPROCS goroutines.
+// See MapErr for a version supporting errors and cancellation.
 func Map[S ~[]X, X, Y any](in S, f func(X) Y) []Y {
-	if len(in) == 0 {
-		return []Y{}
-	}
-
-	N := runtime.GOMAXPROCS(0)
-	inchunks := Divvy(in, N)
 	out := make([]Y, len(in))
-	outchunks := Divvy(out, N)
-
-	var wg sync.WaitGroup
+	forChunks(len(in), func(_, _, lo, hi int) {
+		for i, e := range in[lo:hi] {
+			out[lo+i] = f(e)
+		}
+	})
+	return out
+}
 
-	for i := range inchunks {
-		wg.Add(1)
-		inchunk, outchunk := inchunks[i], outchunks[i]
+// Reduce reduces a slice to a single value. For consistent results, f should
+// be associative and the zero value for E should be an identity element.
+// It does the reduction in parallel, using up to GOMAXPROCS goroutines.
+func Reduce[S ~[]E, E any](in S, f func(E, E) E) E {
+	p := planChunks(len(in))
+	out := make([]E, p.chunks)
+	p.run(func(_, c, lo, hi int) {
+		for _, e := range in[lo:hi] {
+			out[c] = f(out[c], e)
+		}
+	})
+	return algo.Foldl(out, f)
+}
 
-		go func() {
-			defer wg.Done()
+// Scan returns the partial sums (out[i] = in[0] + in[1] + ... + in[i]),
+// like algo.PartialSums. It sums chunks of the input in parallel, using up to
+// GOMAXPROCS goroutines, and then adds the total of the preceding chunks to
+// each chunk in parallel. (Floating-point results may therefore differ
+// slightly from algo.PartialSums.)
+func Scan[S ~[]E, E algo.Addable](in S) S {
+	out := make(S, len(in))
+	p := planChunks(len(in))
+	totals := make([]E, p.chunks)
+	p.run(func(_, c, lo, hi int) {
+		var accum E
+		for i, x := range in[lo:hi] {
+			accum += x
+			out[lo+i] = accum
+		}
+		totals[c] = accum
+	})
+	if len(totals) <= 1 {
+		return out
+	}
+	offsets := algo.PartialSums(totals)
+	p.run(func(_, c, lo, hi int) {
+		if c == 0 {
+			return
+		}
+		for i := lo; i < hi; i++ {
+			out[i] = offsets[c-1] + out[i]
+		}
+	})
+	return out
+}
 
-			for j, e := range inchunk {
-				outchunk[j] = f(e)
+// Filter returns a new slice containing the elements of in for which f
+// returns true, in their original order. It calls f in parallel, using up to
+// GOMAXPROCS goroutines.
+func Filter[S ~[]E, E any](in S, f func(E) bool) S {
+	p := planChunks(len(in))
+	kept := make([]S, p.chunks)
+	p.run(func(_, c, lo, hi int) {
+		for _, e := range in[lo:hi] {
+			if f(e) {
+				kept[c] = append(kept[c], e)
 			}
-		}()
+		}
+	})
+	n := 0
+	for _, k := range kept {
+		n += len(k)
+	}
+	out := make(S, 0, n)
+	for _, k := range kept {
+		out = append(out, k...)
 	}
-
-	wg.Wait()
 	return out
 }
 
-// Reduce reduces a slice to a single value. For consistent results, f should
-// be associative and the zero value for E should be an identity element.
-// It does the reduction in parallel, using up to GOMAXPROCS goroutines.
-func Reduce[S ~[]E, E any](in S, f func(E, E) E) E {
-	if len(in) == 0 {
-		var zero E
-		return zero
+// Freq counts the frequency of each item in a slice, like algo.Freq. Each
+// goroutine (up to GOMAXPROCS) counts into its own map, and the maps are
+// merged at the end.
+func Freq[S ~[]E, E comparable](in S) map[E]int {
+	p := planChunks(len(in))
+	hs := make([]map[E]int, p.workers)
+	p.run(func(w, _, lo, hi int) {
+		h := hs[w]
+		if h == nil {
+			h = make(map[E]int)
+			hs[w] = h
+		}
+		for _, x := range in[lo:hi] {
+			h[x]++
+		}
+	})
+	var h map[E]int
+	for _, g := range hs {
+		if h == nil {
+			h = g
+			continue
+		}
+		for x, c := range g {
+			h[x] += c
+		}
+	}
+	if h == nil {
+		h = make(map[E]int)
+	}
+	return h
+}
+
+// chunksPerWorker is the number of chunks forChunks aims to give each
+// goroutine. More chunks balance uneven work better, at the cost of more
+// contention on the shared counter.
+const chunksPerWorker = 16
+
+// chunkPlan describes how [0, n) is split into chunks and shared among
+// goroutines. It is computed once per call (GOMAXPROCS can change at any
+// time), so that slices sized from it agree with the indexes passed to f.
+type chunkPlan struct {
+	n       int // number of elements
+	size    int // number of elements per chunk (except the last)
+	chunks  int // number of chunks
+	workers int // number of goroutines
+}
+
+// gomaxprocs returns the current GOMAXPROCS. It is a variable so that tests
+// can simulate GOMAXPROCS changing.
+var gomaxprocs = func() int { return runtime.GOMAXPROCS(0) }
+
+// planChunks returns the plan for n elements, based on the current
+// GOMAXPROCS.
+func planChunks(n int) chunkPlan {
+	procs := gomaxprocs()
+	size := max(1, n/(chunksPerWorker*procs))
+	chunks := (n + size - 1) / size
+	return chunkPlan{
+		n:       n,
+		size:    size,
+		chunks:  chunks,
+		workers: min(procs, chunks),
 	}
+}
 
-	chunks := Divvy(in, runtime.GOMAXPROCS(0))
-	out := make([]E, len(chunks))
+// forChunks calls planChunks(n).run(f).
+func forChunks(n int, f func(w, c, lo, hi int)) { planChunks(n).run(f) }
+
+// run calls f with the index of the calling goroutine (w, from 0 to
+// p.workers-1), and the index (c, from 0 to p.chunks-1) and bounds of each
+// chunk, in parallel using p.workers goroutines. Rather than dividing the
+// chunks between the goroutines up front (as with Divvy), each goroutine
+// takes the next chunk when it is ready. So when some elements take much
+// longer than others, goroutines that would otherwise be idle take on more of
+// the remaining work.
+func (p chunkPlan) run(f func(w, c, lo, hi int)) {
+	if p.n == 0 {
+		return
+	}
+	if p.chunks == 1 {
+		f(0, 0, 0, p.n)
+		return
+	}
 
+	var next atomic.Int64
 	var wg sync.WaitGroup
 
-	for i, chunk := range chunks {
+	for w := range p.workers {
 		wg.Add(1)
-		i, chunk := i, chunk
 
 		go func() {
 			defer wg.Done()
 
-			for _, e := range chunk {
-				out[i] = f(out[i], e)
+			for {
+				c := int(next.Add(1) - 1)
+				if c >= p.chunks {
+					return
+				}
+				lo := c * p.size
+				f(w, c, lo, min(lo+p.size, p.n))
 			}
 		}()
 	}
 
 	wg.Wait()
-	return algo.Foldl(out, f)
 }
 
 // Divvy divides a slice into up to n subslices of approximately equal size.
@@ -1,10 +1,16 @@
 package para
 
 import (
+	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"drjosh.dev/exp/algo"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 )
 
 func TestMap(t *testing.T) {
@@ -18,3 +24,205 @@ func TestMap(t *testing.T) {
 		}
 	}
 }
+
+func TestDo(t *testing.T) {
+	for N := 0; N < 300; N++ {
+		seen := make([]int, N)
+		Do(make([]int, N), func(int) {})
+		in := make([]int, N)
+		for i := range in {
+			in[i] = i
+		}
+		Do(in, func(x int) { seen[x]++ })
+		for i, c := range seen {
+			if c != 1 {
+				t.Errorf("Do([]int of len %d): element %d visited %d times, want 1", N, i, c)
+			}
+		}
+	}
+}
+
+func TestReduce(t *testing.T) {
+	// String concatenation is associative but not commutative, so this checks
+	// that the chunks are combined in order.
+	for N := 0; N < 300; N++ {
+		in := make([]string, N)
+		for i := range in {
+			in[i] = string(rune('a' + i%26))
+		}
+		concat := func(x, y string) string { return x + y }
+		got := Reduce(in, concat)
+		want := algo.Foldl(in, concat)
+		if got != want {
+			t.Errorf("Reduce([]string of len %d) = %q, want %q", N, got, want)
+		}
+	}
+}
+
+// staticDo is the previous implementation of Do, which splits the input into
+// one chunk per goroutine with Divvy.
+// changingGOMAXPROCS makes gomaxprocs return a different value each time it
+// is called, until the returned function is called.
+func changingGOMAXPROCS() (restore func()) {
+	orig := gomaxprocs
+	var calls atomic.Int64
+	gomaxprocs = func() int { return []int{1, 8, 3}[calls.Add(1)%3] }
+	return func() { gomaxprocs = orig }
+}
+
+func TestChangingGOMAXPROCS(t *testing.T) {
+	defer changingGOMAXPROCS()()
+
+	in := make([]int, 1000)
+	for i := range in {
+		in[i] = i
+	}
+	add := func(x, y int) int { return x + y }
+	for range 10 {
+		if got, want := Reduce(in, add), 999*1000/2; got != want {
+			t.Fatalf("Reduce(in, add) = %d, want %d", got, want)
+		}
+		if got := Map(in, func(x int) int { return x }); !slices.Equal(got, in) {
+			t.Fatalf("Map(in, identity) = %v, want %v", got, in)
+		}
+		if got, want := Scan(in), algo.PartialSums(in); !slices.Equal(got, want) {
+			t.Fatalf("Scan(in) = %v, want %v", got, want)
+		}
+		if got := Filter(in, func(int) bool { return true }); !slices.Equal(got, in) {
+			t.Fatalf("Filter(in, true) = %v, want %v", got, in)
+		}
+		if got := Freq(in); len(got) != len(in) {
+			t.Fatalf("len(Freq(in)) = %d, want %d", len(got), len(in))
+		}
+	}
+}
+
+func staticDo[S ~[]E, E any](in S, f func(E)) {
+	var wg sync.WaitGroup
+	for _, chunk := range Divvy(in, runtime.GOMAXPROCS(0)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, e := range chunk {
+				f(e)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// spin does an amount of busy work proportional to n.
+func spin(n int) {
+	x := uint64(n)
+	for range n * 1000 {
+		x ^= x<<13 ^ x>>7 ^ x<<17
+	}
+	spinSink.Add(x)
+}
+
+var spinSink atomic.Uint64
+
+// skewed returns a workload where the first eighth of the items are 100
+// times as expensive as the rest, as with search states at different depths.
+func skewed(n int) []int {
+	in := make([]int, n)
+	for i := range in {
+		in[i] = 1
+		if i < len(in)/8 {
+			in[i] = 100
+		}
+	}
+	return in
+}
+
+func BenchmarkDoSkewed(b *testing.B) {
+	in := skewed(4096)
+	b.Run("static", func(b *testing.B) {
+		for range b.N {
+			staticDo(in, spin)
+		}
+	})
+	b.Run("dynamic", func(b *testing.B) {
+		for range b.N {
+			Do(in, spin)
+		}
+	})
+}
+
+// BenchmarkDoSkewedBlocking is like BenchmarkDoSkewed, but the work blocks
+// rather than using the CPU (as with reading files of very different sizes),
+// so the difference is visible even with few CPUs.
+func BenchmarkDoSkewedBlocking(b *testing.B) {
+	in := skewed(512)
+	nap := func(n int) { time.Sleep(time.Duration(n) * 10 * time.Microsecond) }
+	b.Run("static", func(b *testing.B) {
+		for range b.N {
+			staticDo(in, nap)
+		}
+	})
+	b.Run("dynamic", func(b *testing.B) {
+		for range b.N {
+			Do(in, nap)
+		}
+	})
+}
+
+func BenchmarkDoUniform(b *testing.B) {
+	in := make([]int, 4096)
+	for i := range in {
+		in[i] = 1
+	}
+	b.Run("static", func(b *testing.B) {
+		for range b.N {
+			staticDo(in, spin)
+		}
+	})
+	b.Run("dynamic", func(b *testing.B) {
+		for range b.N {
+			Do(in, spin)
+		}
+	})
+}
+
+func TestScan(t *testing.T) {
+	for N := 0; N < 3000; N += 37 {
+		in := make([]int, N)
+		for i := range in {
+			in[i] = i*7%13 - 6
+		}
+		got := Scan(in)
+		want := algo.PartialSums(in)
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Errorf("Scan([]int of len %d) diff (-got +want):\n%s", len(in), diff)
+		}
+	}
+}
+
+func TestFilter(t *testing.T) {
+	even := func(x int) bool { return x%2 == 0 }
+	for N := 0; N < 3000; N += 37 {
+		in := make([]int, N)
+		for i := range in {
+			in[i] = i * 7 % 13
+		}
+		got := Filter(in, even)
+		want := slices.Collect(algo.Filt(slices.Values(in), even))
+		if diff := cmp.Diff(got, want, cmpopts.EquateEmpty()); diff != "" {
+			t.Errorf("Filter([]int of len %d) diff (-got +want):\n%s", len(in), diff)
+		}
+	}
+}
+
+func TestFreq(t *testing.T) {
+	for N := 0; N < 3000; N += 37 {
+		in := make([]int, N)
+		for i := range in {
+			in[i] = i * 7 % 13
+		}
+		got := Freq(in)
+		want := algo.Freq(in)
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Errorf("Freq([]int of len %d) diff (-got +want):\n%s", len(in), diff)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package para
+
+import (
+	"cmp"
+	"context"
+
+	"drjosh.dev/exp/algo"
+)
+
+// PriQueue implements a goroutine-safe priority queue of items of type T each
+// having a priority of type D, processed in parallel. Workers always take the
+// item with the least priority value of those queued, so PriQueue suits
+// parallel best-first search.
+//
+// For branch-and-bound searches, PriQueue also holds an optional shared bound
+// (the cost of the best solution found so far). Once a bound has been set,
+// items with a priority not less than the bound are discarded without being
+// processed, and workers can use Bound to prune their own work.
+//
+// The zero value is an empty queue with no bound.
+type PriQueue[T any, D cmp.Ordered] struct {
+	// Workers is the number of goroutines used by Process and ProcessCtx.
+	// If Workers <= 0, GOMAXPROCS is used.
+	Workers int
+
+	sched
+	items   algo.PriQueue[T, D]
+	bound   D
+	bounded bool
+}
+
+// Process calls f on each item in the queue in parallel (with q.Workers
+// goroutines), in priority order (as far as concurrency allows). f can
+// enqueue more items by calling q.Push. It blocks until the queue is empty
+// and all items have been processed.
+func (q *PriQueue[T, D]) Process(f func(T, D)) {
+	q.ProcessCtx(context.Background(), func(t T, d D) error {
+		f(t, d)
+		return nil
+	})
+}
+
+// ProcessCtx calls f on each item in the queue in parallel (with q.Workers
+// goroutines), in priority order (as far as concurrency allows). Like
+// Queue.ProcessCtx, it stops early if f returns an error or panics, or if ctx
+// is cancelled.
+func (q *PriQueue[T, D]) ProcessCtx(ctx context.Context, f func(T, D) error) error {
+	return process(ctx, &q.sched, q.Workers, func() (algo.WeightedItem[T, D], bool) {
+		for q.items.Len() > 0 {
+			t, d := q.items.Pop()
+			if q.bounded && d >= q.bound {
+				q.done()
+				continue
+			}
+			return algo.WeightedItem[T, D]{Item: t, Weight: d}, true
+		}
+		return algo.WeightedItem[T, D]{}, false
+	}, func(wi algo.WeightedItem[T, D]) error {
+		return f(wi.Item, wi.Weight)
+	})
+}
+
+// Push adds an item to the queue with a priority. If the priority is not less
+// than the bound, the item is discarded.
+func (q *PriQueue[T, D]) Push(item T, priority D) {
+	q.lock()
+	if q.bounded && priority >= q.bound {
+		q.µ.Unlock()
+		return
+	}
+	q.items.Push(item, priority)
+	q.pushed(1)
+}
+
+// Len returns the number of items in the queue (not including any being
+// processed).
+func (q *PriQueue[T, D]) Len() int {
+	q.lock()
+	defer q.µ.Unlock()
+	return q.items.Len()
+}
+
+// Bound returns the current bound, and whether one has been set.
+func (q *PriQueue[T, D]) Bound() (D, bool) {
+	q.lock()
+	defer q.µ.Unlock()
+	return q.bound, q.bounded
+}
+
+// ImproveBound sets the bound to b, if no bound has been set or b is less
+// than the current bound. It reports whether the bound was changed.
+func (q *PriQueue[T, D]) ImproveBound(b D) bool {
+	q.lock()
+	defer q.µ.Unlock()
+	if q.bounded && b >= q.bound {
+		return false
+	}
+	q.bound, q.bounded = b, true
+	return true
+}
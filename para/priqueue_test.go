@@ -0,0 +1,96 @@
+package para
+
+import (
+	"math/rand/v2"
+	"slices"
+	"sync/atomic"
+	"testing"
+)
+
+func TestPriQueueOrder(t *testing.T) {
+	var q PriQueue[string, int]
+	q.Workers = 1
+	q.Push("c", 3)
+	q.Push("a", 1)
+	q.Push("d", 4)
+
+	var got []string
+	q.Process(func(s string, p int) {
+		got = append(got, s)
+		if s == "a" {
+			q.Push("b", 2)
+		}
+	})
+	if want := []string{"a", "b", "c", "d"}; !slices.Equal(got, want) {
+		t.Errorf("processed %v, want %v", got, want)
+	}
+}
+
+func TestPriQueueBranchAndBound(t *testing.T) {
+	// Find the cheapest root-to-leaf path in a complete binary tree with
+	// random edge weights, where node n has children 2n and 2n+1.
+	const depth = 14
+	r := rand.New(rand.NewPCG(1, 2))
+	weight := make([]int, 1<<(depth+1))
+	for i := range weight {
+		weight[i] = r.IntN(100)
+	}
+	var cheapest func(n int) int
+	cheapest = func(n int) int {
+		if n >= 1<<depth {
+			return 0
+		}
+		return min(weight[2*n]+cheapest(2*n), weight[2*n+1]+cheapest(2*n+1))
+	}
+	want := cheapest(1)
+
+	var q PriQueue[int, int]
+	q.Push(1, 0)
+	var processed atomic.Int64
+	q.Process(func(n, cost int) {
+		processed.Add(1)
+		if n >= 1<<depth {
+			q.ImproveBound(cost)
+			return
+		}
+		if b, ok := q.Bound(); ok && cost >= b {
+			return
+		}
+		q.Push(2*n, cost+weight[2*n])
+		q.Push(2*n+1, cost+weight[2*n+1])
+	})
+
+	got, ok := q.Bound()
+	if !ok || got != want {
+		t.Errorf("q.Bound() = %d, %t, want %d, true", got, ok, want)
+	}
+	if n := processed.Load(); n >= 1<<(depth+1)-1 {
+		t.Errorf("processed %d nodes, want fewer than the whole tree", n)
+	}
+	if q.Len() != 0 {
+		t.Errorf("q.Len() = %d, want 0", q.Len())
+	}
+}
+
+func TestPriQueueImproveBound(t *testing.T) {
+	var q PriQueue[string, float64]
+	if _, ok := q.Bound(); ok {
+		t.Errorf("q.Bound() ok = true on zero queue, want false")
+	}
+	if !q.ImproveBound(5) || q.ImproveBound(7) || !q.ImproveBound(3) {
+		t.Errorf("ImproveBound(5, 7, 3) didn't report true, false, true")
+	}
+	q.Push("pruned", 3)
+	q.Push("kept", 2.5)
+	if got, want := q.Len(), 1; got != want {
+		t.Errorf("q.Len() = %d, want %d", got, want)
+	}
+
+	// Items already queued are discarded once the bound passes them.
+	q.ImproveBound(2)
+	calls := 0
+	q.Process(func(string, float64) { calls++ })
+	if calls != 0 || q.Len() != 0 {
+		t.Errorf("after Process, calls = %d and q.Len() = %d, want 0 and 0", calls, q.Len())
+	}
+}
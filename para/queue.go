@@ -1,69 +1,234 @@
 package para
 
 import (
+	"context"
 	"runtime"
 	"sync"
 )
 
-// Queue implements a goroutine-safe queue of items based on a channel.
+// Queue implements a goroutine-safe queue of items. There is no limit on the
+// number of queued items, other than available memory: the internal buffer
+// grows and shrinks as needed. The zero value is an empty queue.
 type Queue[E any] struct {
-	µ  sync.Mutex
-	c  int
-	ch chan E
+	// Workers is the number of goroutines used by Process and ProcessCtx.
+	// If Workers <= 0, GOMAXPROCS is used.
+	Workers int
+
+	sched
+	items deque[E]
 }
 
 // NewQueue returns a new queue with an initial list of items.
 func NewQueue[E any](items ...E) *Queue[E] {
-	q := &Queue[E]{
-		ch: make(chan E, max(65536, len(items))),
-	}
-	q.c = len(items)
-	for _, e := range items {
-		q.ch <- e
-	}
+	q := new(Queue[E])
+	q.Push(items...)
 	return q
 }
 
-// Process calls f on each item in the queue in parallel (with GOMAXPROCS
+// Process calls f on each item in the queue in parallel (with q.Workers
 // goroutines). f can enqueue more items by calling q.Push. It blocks until the
 // queue is empty and all items have been processed.
 func (q *Queue[E]) Process(f func(E)) {
-	N := runtime.GOMAXPROCS(0)
+	q.ProcessCtx(context.Background(), func(e E) error {
+		f(e)
+		return nil
+	})
+}
+
+// ProcessCtx calls f on each item in the queue in parallel (with q.Workers
+// goroutines). f can enqueue more items by calling q.Push. It blocks until
+// either the queue is empty and all items have been processed, or processing
+// stops early because f returned an error or panicked, or ctx was cancelled.
+// When stopping early, ProcessCtx waits for calls to f already in progress to
+// return, and then returns the first error from f (panics are returned as a
+// *PanicError), or ctx.Err(). Items not yet processed remain in the queue.
+func (q *Queue[E]) ProcessCtx(ctx context.Context, f func(E) error) error {
+	return process(ctx, &q.sched, q.Workers, func() (E, bool) {
+		if q.items.n == 0 {
+			var zero E
+			return zero, false
+		}
+		return q.items.popFront(), true
+	}, f)
+}
+
+// Push appends to the end of the queue.
+func (q *Queue[E]) Push(items ...E) {
+	if len(items) == 0 {
+		return
+	}
+	q.lock()
+	for _, e := range items {
+		q.items.pushBack(e)
+	}
+	q.pushed(len(items))
+}
+
+// Len returns the number of items in the queue (not including any being
+// processed).
+func (q *Queue[E]) Len() int {
+	q.lock()
+	defer q.µ.Unlock()
+	return q.items.n
+}
+
+// sched holds the state for coordinating workers processing a queue.
+type sched struct {
+	µ       sync.Mutex
+	cond    sync.Cond // signalled when items are pushed, or processing stops
+	pending int       // items queued or being processed
+}
+
+// lock locks s.µ, initialising s.cond if needed.
+func (s *sched) lock() {
+	s.µ.Lock()
+	if s.cond.L == nil {
+		s.cond.L = &s.µ
+	}
+}
+
+// pushed records that n items were added, unlocks s.µ, and wakes waiting
+// workers.
+func (s *sched) pushed(n int) {
+	s.pending += n
+	s.µ.Unlock()
+	if n == 1 {
+		s.cond.Signal()
+	} else {
+		s.cond.Broadcast()
+	}
+}
+
+// done records that an item has been processed (or discarded). If there are
+// no outstanding items and no queued items, waiting workers are woken. s.µ
+// must be held.
+func (s *sched) done() {
+	s.pending--
+	if s.pending == 0 {
+		s.cond.Broadcast()
+	}
+}
+
+// process calls f on items obtained from take, using workers goroutines (or
+// GOMAXPROCS, if workers <= 0), until there are no more items or processing
+// stops. take is called with s.µ held, and returns false if there are
+// currently no items to take. (take may discard items, by calling s.done.)
+func process[E any](ctx context.Context, s *sched, workers int, take func() (E, bool), f func(E) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// stop and firstErr belong to this call (guarded by s.µ), so that a
+	// cancellation callback from an earlier call can't stop this one.
+	var (
+		stop     bool
+		firstErr error
+	)
+	halt := func(err error) {
+		if firstErr == nil {
+			firstErr = err
+		}
+		stop = true
+		s.cond.Broadcast()
+	}
+
+	stopAfter := context.AfterFunc(ctx, func() {
+		s.lock()
+		halt(nil)
+		s.µ.Unlock()
+	})
+	defer stopAfter()
+
+	// next waits for an item to process. It returns false if processing should
+	// stop, or there are no more items and none are being processed.
+	next := func() (E, bool) {
+		s.lock()
+		defer s.µ.Unlock()
+		for !stop && s.pending > 0 {
+			if e, ok := take(); ok {
+				return e, true
+			}
+			if s.pending == 0 {
+				break
+			}
+			s.cond.Wait()
+		}
+		var zero E
+		return zero, false
+	}
+
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
 	var wg sync.WaitGroup
 
-	for i := 0; i < N; i++ {
+	for range workers {
 		wg.Add(1)
 
 		go func() {
 			defer wg.Done()
 
-			for e := range q.ch {
-				f(e)
-				q.done()
+			for {
+				e, ok := next()
+				if !ok {
+					return
+				}
+				err := call(func() error { return f(e) })
+				s.lock()
+				s.done()
+				if err != nil {
+					halt(err)
+				}
+				s.µ.Unlock()
 			}
 		}()
 	}
 
 	wg.Wait()
+
+	s.lock()
+	defer s.µ.Unlock()
+	if firstErr != nil {
+		return firstErr
+	}
+	if stop && s.pending > 0 {
+		return ctx.Err()
+	}
+	return nil
 }
 
-// Push appends to the end of the queue.
-func (q *Queue[E]) Push(items ...E) {
-	for _, e := range items {
-		q.µ.Lock()
-		q.c++
-		q.ch <- e
-		q.µ.Unlock()
+// deque is a FIFO queue implemented with a ring buffer that grows and shrinks
+// as needed.
+type deque[E any] struct {
+	buf  []E
+	head int // index of the first item
+	n    int // number of items
+}
+
+func (d *deque[E]) pushBack(e E) {
+	if d.n == len(d.buf) {
+		d.resize(max(16, 2*len(d.buf)))
 	}
+	d.buf[(d.head+d.n)%len(d.buf)] = e
+	d.n++
 }
 
-// done decrements the queue's internal counter. If there are no outstanding
-// items and no queued items, the channel is closed.
-func (q *Queue[E]) done() {
-	q.µ.Lock()
-	q.c--
-	if q.c == 0 {
-		close(q.ch)
+func (d *deque[E]) popFront() E {
+	var zero E
+	e := d.buf[d.head]
+	d.buf[d.head] = zero // allow e to be garbage collected
+	d.head = (d.head + 1) % len(d.buf)
+	d.n--
+	if len(d.buf) > 16 && d.n < len(d.buf)/4 {
+		d.resize(len(d.buf) / 2)
 	}
-	q.µ.Unlock()
+	return e
+}
+
+// resize moves the items into a new buffer of the given size.
+func (d *deque[E]) resize(size int) {
+	buf := make([]E, size)
+	k := copy(buf, d.buf[d.head:min(d.head+d.n, len(d.buf))])
+	copy(buf[k:], d.buf[:d.n-k])
+	d.buf, d.head = buf, 0
 }
@@ -1,6 +1,8 @@
 package para
 
 import (
+	"context"
+	"errors"
 	"sync/atomic"
 	"testing"
 )
@@ -21,3 +23,142 @@ func TestQueue(t *testing.T) {
 		t.Errorf("sum = %d, want %d", got, want)
 	}
 }
+
+func TestQueueBeyondChannelBuffer(t *testing.T) {
+	// Each item pushes two more until there are over 200000 in total, with
+	// more than 65536 pending at once.
+	const limit = 1 << 18
+	q := NewQueue(1)
+	q.Workers = 4
+
+	var count atomic.Int64
+	q.Process(func(x int) {
+		count.Add(1)
+		if x < limit/2 {
+			q.Push(2*x, 2*x+1)
+		}
+	})
+
+	if got, want := int(count.Load()), limit-1; got != want {
+		t.Errorf("count = %d, want %d", got, want)
+	}
+	if got := q.Len(); got != 0 {
+		t.Errorf("q.Len() = %d, want 0", got)
+	}
+}
+
+func TestQueueProcessCtxError(t *testing.T) {
+	errBoom := errors.New("boom")
+	q := NewQueue(1)
+	q.Workers = 1
+
+	var calls int
+	err := q.ProcessCtx(context.Background(), func(x int) error {
+		calls++
+		if x == 5 {
+			return errBoom
+		}
+		q.Push(x + 1)
+		return nil
+	})
+	if !errors.Is(err, errBoom) {
+		t.Errorf("ProcessCtx() error = %v, want %v", err, errBoom)
+	}
+	if calls != 5 {
+		t.Errorf("ProcessCtx() made %d calls, want 5", calls)
+	}
+
+	// Panics are errors too, and processing can resume afterwards.
+	q.Push(100, 101)
+	err = q.ProcessCtx(context.Background(), func(x int) error {
+		if x == 100 {
+			panic("oops")
+		}
+		return nil
+	})
+	var pe *PanicError
+	if !errors.As(err, &pe) {
+		t.Fatalf("ProcessCtx() error = %v, want *PanicError", err)
+	}
+	if got, want := q.Len(), 1; got != want {
+		t.Errorf("q.Len() = %d, want %d", got, want)
+	}
+	if err := q.ProcessCtx(context.Background(), func(int) error { return nil }); err != nil {
+		t.Errorf("ProcessCtx() error = %v, want nil", err)
+	}
+}
+
+func TestQueueProcessCtxCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	q := NewQueue(0)
+
+	var calls atomic.Int64
+	err := q.ProcessCtx(ctx, func(x int) error {
+		if calls.Add(1) == 1000 {
+			cancel()
+		}
+		q.Push(x + 1)
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("ProcessCtx() error = %v, want %v", err, context.Canceled)
+	}
+	if got := q.Len(); got == 0 {
+		t.Errorf("q.Len() = 0 after cancellation, want unprocessed items")
+	}
+}
+
+func TestQueueProcessCtxAfterCancel(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Repeat, since a stale cancellation from the first call would only
+	// sometimes stop the second.
+	for range 100 {
+		q := NewQueue(make([]int, 100)...)
+		var calls atomic.Int64
+		f := func(int) error {
+			calls.Add(1)
+			return nil
+		}
+		if err := q.ProcessCtx(cancelled, f); !errors.Is(err, context.Canceled) {
+			t.Fatalf("ProcessCtx(cancelled) error = %v, want %v", err, context.Canceled)
+		}
+		if err := q.ProcessCtx(context.Background(), f); err != nil {
+			t.Fatalf("ProcessCtx() error = %v, want nil", err)
+		}
+		if got := calls.Load(); got != 100 {
+			t.Fatalf("calls = %d, want 100", got)
+		}
+		if got := q.Len(); got != 0 {
+			t.Fatalf("q.Len() = %d, want 0", got)
+		}
+	}
+}
+
+func TestDeque(t *testing.T) {
+	var d deque[int]
+	next, want := 0, 0
+	// Interleave pushes and pops so the ring buffer wraps, grows and shrinks.
+	for round := range 10 {
+		for range 100 * (round + 1) {
+			d.pushBack(next)
+			next++
+		}
+		for d.n > 50*round {
+			if got := d.popFront(); got != want {
+				t.Fatalf("popFront() = %d, want %d", got, want)
+			}
+			want++
+		}
+	}
+	for d.n > 0 {
+		if got := d.popFront(); got != want {
+			t.Fatalf("popFront() = %d, want %d", got, want)
+		}
+		want++
+	}
+	if want != next {
+		t.Errorf("popped %d items, want %d", want, next)
+	}
+}
@@ -0,0 +1,74 @@
+package para
+
+import (
+	"cmp"
+	"math/bits"
+	"runtime"
+	"slices"
+	"sync"
+)
+
+// sortCutoff is the length below which a subslice is sorted sequentially.
+const sortCutoff = 2048
+
+// Sort sorts a slice of any ordered type in ascending order, using a parallel
+// merge sort with up to GOMAXPROCS goroutines. The sort is stable.
+func Sort[S ~[]E, E cmp.Ordered](s S) {
+	SortFunc(s, cmp.Compare[E])
+}
+
+// SortFunc sorts the slice in ascending order as determined by the cmp
+// function (as for slices.SortFunc), using a parallel merge sort with up to
+// GOMAXPROCS goroutines. The sort is stable.
+func SortFunc[S ~[]E, E any](s S, cmp func(a, b E) int) {
+	// Each level of recursion doubles the number of goroutines.
+	depth := bits.Len(uint(runtime.GOMAXPROCS(0) - 1))
+	if depth == 0 || len(s) <= sortCutoff {
+		slices.SortStableFunc(s, cmp)
+		return
+	}
+	mergeSort(s, make(S, len(s)), cmp, depth)
+}
+
+// mergeSort sorts s stably, using buf (which must be the same length as s)
+// as scratch space. Up to depth levels of recursion happen in parallel.
+func mergeSort[S ~[]E, E any](s, buf S, cmp func(a, b E) int, depth int) {
+	if len(s) <= sortCutoff {
+		slices.SortStableFunc(s, cmp)
+		return
+	}
+	mid := len(s) / 2
+	if depth > 0 {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			mergeSort(s[:mid], buf[:mid], cmp, depth-1)
+		}()
+		mergeSort(s[mid:], buf[mid:], cmp, depth-1)
+		wg.Wait()
+	} else {
+		mergeSort(s[:mid], buf[:mid], cmp, 0)
+		mergeSort(s[mid:], buf[mid:], cmp, 0)
+	}
+	merge(s[:mid], s[mid:], buf, cmp)
+	copy(s, buf)
+}
+
+// merge merges the sorted slices a and b into out. Where elements are equal,
+// those from a come first.
+func merge[S ~[]E, E any](a, b, out S, cmp func(a, b E) int) {
+	i, j, k := 0, 0, 0
+	for i < len(a) && j < len(b) {
+		if cmp(b[j], a[i]) < 0 {
+			out[k] = b[j]
+			j++
+		} else {
+			out[k] = a[i]
+			i++
+		}
+		k++
+	}
+	k += copy(out[k:], a[i:])
+	copy(out[k:], b[j:])
+}
@@ -0,0 +1,79 @@
+package para
+
+import (
+	"math/rand/v2"
+	"runtime"
+	"slices"
+	"testing"
+
+	"drjosh.dev/exp/algo"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSort(t *testing.T) {
+	// Force some parallelism, even on machines with one CPU.
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	r := rand.New(rand.NewPCG(1, 2))
+	for _, N := range []int{0, 1, 100, sortCutoff, sortCutoff + 1, 50000} {
+		in := make([]int, N)
+		for i := range in {
+			in[i] = r.IntN(1000)
+		}
+		got := slices.Clone(in)
+		Sort(got)
+		want := slices.Clone(in)
+		algo.SortAsc(want)
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Errorf("Sort([]int of len %d) diff (-got +want):\n%s", N, diff)
+		}
+	}
+}
+
+func TestSortFuncStable(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	type item struct{ key, index int }
+	r := rand.New(rand.NewPCG(3, 4))
+	in := make([]item, 20000)
+	for i := range in {
+		in[i] = item{key: r.IntN(10), index: i}
+	}
+	key := func(it item) int { return it.key }
+
+	got := slices.Clone(in)
+	SortFunc(got, func(a, b item) int { return a.key - b.key })
+	want := slices.Clone(in)
+	algo.SortByFuncAsc(want, key)
+	if diff := cmp.Diff(algo.Map(got, key), algo.Map(want, key)); diff != "" {
+		t.Errorf("SortFunc keys diff (-got +want):\n%s", diff)
+	}
+
+	// Items with equal keys stay in their original order.
+	for i := 1; i < len(got); i++ {
+		if got[i-1].key == got[i].key && got[i-1].index > got[i].index {
+			t.Fatalf("SortFunc is not stable: %v before %v", got[i-1], got[i])
+		}
+	}
+}
+
+func BenchmarkSort(b *testing.B) {
+	r := rand.New(rand.NewPCG(1, 2))
+	in := make([]int, 1<<20)
+	for i := range in {
+		in[i] = r.Int()
+	}
+	s := make([]int, len(in))
+	b.Run("para", func(b *testing.B) {
+		for range b.N {
+			copy(s, in)
+			Sort(s)
+		}
+	})
+	b.Run("slices", func(b *testing.B) {
+		for range b.N {
+			copy(s, in)
+			slices.Sort(s)
+		}
+	})
+}
@@ -0,0 +1,445 @@
+/*
+   Copyright 2026 Josh Deprez
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+	   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package parse
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// This file implements a small parser-combinator toolkit. A Parser is a
+// function from an Input to a result and the remaining Input. Since Input is
+// an immutable value, alternatives backtrack for free.
+//
+// For example, a parser for nested lists of integers like [1,[2,3],[]]:
+//
+//	var list parse.Parser[any]
+//	elem := parse.Alt(parse.Map(parse.Int, func(n int) any { return n }), parse.Lazy(&list))
+//	list = parse.Map(parse.Between(parse.Lit("["), parse.SepBy(elem, parse.Lit(",")), parse.Lit("]")),
+//		func(xs []any) any { return xs })
+
+// Pos is a position within the input.
+type Pos struct {
+	Offset int // 0-based byte offset
+	Line   int // 1-based line number
+	Col    int // 1-based column (in runes)
+}
+
+func (p Pos) String() string { return fmt.Sprintf("%d:%d", p.Line, p.Col) }
+
+// Input is the remaining text to parse, together with its position in the
+// original text.
+type Input struct {
+	src string
+	pos Pos
+
+	// far tracks the furthest failure seen by any parser working on the same
+	// original text. Because many combinators (Many, Optional, ...) succeed
+	// by swallowing a failure, the furthest failure is a better description
+	// of what went wrong than the failure that is ultimately returned.
+	far *farthest
+}
+
+type farthest struct{ err error }
+
+// NewInput returns an Input for parsing s from the beginning.
+func NewInput(s string) Input {
+	return Input{src: s, pos: Pos{Line: 1, Col: 1}, far: new(farthest)}
+}
+
+// Rest returns the remaining text.
+func (in Input) Rest() string { return in.src[in.pos.Offset:] }
+
+// Pos returns the current position.
+func (in Input) Pos() Pos { return in.pos }
+
+// AtEOF reports whether there is no remaining text.
+func (in Input) AtEOF() bool { return in.pos.Offset == len(in.src) }
+
+// Advance returns the input with the next n bytes consumed.
+func (in Input) Advance(n int) Input {
+	for _, r := range in.src[in.pos.Offset:][:n] {
+		if r == '\n' {
+			in.pos.Line++
+			in.pos.Col = 1
+		} else {
+			in.pos.Col++
+		}
+	}
+	in.pos.Offset += n
+	return in
+}
+
+// SyntaxError is returned by parsers that fail. It lists what was expected at
+// the furthest position reached.
+type SyntaxError struct {
+	Pos      Pos
+	Expected []string
+	Err      error // an underlying error (e.g. from strconv), if any
+}
+
+func (e *SyntaxError) Error() string {
+	var msg string
+	switch len(e.Expected) {
+	case 0:
+		msg = "syntax error"
+	case 1:
+		msg = "expected " + e.Expected[0]
+	default:
+		msg = "expected one of " + strings.Join(e.Expected, ", ")
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return fmt.Sprintf("%s at %v", msg, e.Pos)
+}
+
+func (e *SyntaxError) Unwrap() error { return e.Err }
+
+// expected returns a *SyntaxError at the current position.
+func (in Input) expected(what ...string) error {
+	return in.fail(&SyntaxError{Pos: in.pos, Expected: what})
+}
+
+// fail records err as a candidate for the furthest failure, and returns it.
+func (in Input) fail(err error) error {
+	if in.far != nil {
+		in.far.err = furthest(in.far.err, err)
+	}
+	return err
+}
+
+// furthest combines two parse errors, preferring the one that got further,
+// and merging the expectations if they are at the same position.
+func furthest(a, b error) error {
+	sa, oka := a.(*SyntaxError)
+	sb, okb := b.(*SyntaxError)
+	switch {
+	case a == nil:
+		return b
+	case !oka || !okb:
+		return a
+	case sa.Pos.Offset > sb.Pos.Offset:
+		return a
+	case sa.Pos.Offset < sb.Pos.Offset:
+		return b
+	}
+	exp := slices.Clone(sa.Expected)
+	for _, e := range sb.Expected {
+		if !slices.Contains(exp, e) {
+			exp = append(exp, e)
+		}
+	}
+	return &SyntaxError{Pos: sa.Pos, Expected: exp, Err: sa.Err}
+}
+
+// Parser parses a value of type T from the start of the input, returning
+// the value and the remaining input, or an error (normally a *SyntaxError).
+type Parser[T any] func(Input) (T, Input, error)
+
+// Run parses all of s with p. It is an error if p does not consume all of s.
+// The error describes the furthest position any parser reached in s, and
+// what was expected there.
+func Run[T any](p Parser[T], s string) (T, error) {
+	in := NewInput(s)
+	t, rest, err := p(in)
+	if err == nil && !rest.AtEOF() {
+		err = rest.expected("end of input")
+	}
+	if err != nil {
+		return t, furthest(in.far.err, err)
+	}
+	return t, nil
+}
+
+// Lit parses the literal string s.
+func Lit(s string) Parser[string] {
+	want := strconv.Quote(s)
+	return func(in Input) (string, Input, error) {
+		if !strings.HasPrefix(in.Rest(), s) {
+			return "", in, in.expected(want)
+		}
+		return s, in.Advance(len(s)), nil
+	}
+}
+
+// Int parses a decimal integer with an optional leading sign.
+func Int(in Input) (int, Input, error) {
+	rest := in.Rest()
+	n := 0
+	if n < len(rest) && (rest[n] == '-' || rest[n] == '+') {
+		n++
+	}
+	digits := n
+	for n < len(rest) && '0' <= rest[n] && rest[n] <= '9' {
+		n++
+	}
+	if n == digits {
+		return 0, in, in.expected("integer")
+	}
+	x, err := strconv.Atoi(rest[:n])
+	if err != nil {
+		return 0, in, in.fail(&SyntaxError{Pos: in.pos, Expected: []string{"integer"}, Err: err})
+	}
+	return x, in.Advance(n), nil
+}
+
+// Spaces parses zero or more whitespace characters. It never fails.
+func Spaces(in Input) (string, Input, error) {
+	rest := in.Rest()
+	n := len(rest) - len(strings.TrimLeftFunc(rest, unicode.IsSpace))
+	return rest[:n], in.Advance(n), nil
+}
+
+// While parses one or more runes satisfying f. The name is used in error
+// messages.
+func While(name string, f func(rune) bool) Parser[string] {
+	return func(in Input) (string, Input, error) {
+		rest := in.Rest()
+		n := strings.IndexFunc(rest, func(r rune) bool { return !f(r) })
+		if n < 0 {
+			n = len(rest)
+		}
+		if n == 0 {
+			return "", in, in.expected(name)
+		}
+		return rest[:n], in.Advance(n), nil
+	}
+}
+
+// Rune parses a single rune satisfying f. The name is used in error messages.
+func Rune(name string, f func(rune) bool) Parser[rune] {
+	return func(in Input) (rune, Input, error) {
+		r, n := utf8.DecodeRuneInString(in.Rest())
+		if n == 0 || !f(r) {
+			return 0, in, in.expected(name)
+		}
+		return r, in.Advance(n), nil
+	}
+}
+
+// EOF succeeds only at the end of the input.
+func EOF(in Input) (struct{}, Input, error) {
+	if !in.AtEOF() {
+		return struct{}{}, in, in.expected("end of input")
+	}
+	return struct{}{}, in, nil
+}
+
+// Token parses p, then skips any whitespace that follows.
+func Token[T any](p Parser[T]) Parser[T] {
+	return Left(p, Spaces)
+}
+
+// Label replaces the expectation in errors from p that occur at the starting
+// position, so that error messages can describe p as a whole.
+func Label[T any](name string, p Parser[T]) Parser[T] {
+	return func(in Input) (T, Input, error) {
+		var prev error
+		if in.far != nil {
+			prev = in.far.err
+		}
+		t, rest, err := p(in)
+		if se, ok := err.(*SyntaxError); ok && se.Pos.Offset == in.pos.Offset {
+			err = &SyntaxError{Pos: se.Pos, Expected: []string{name}, Err: se.Err}
+			if in.far != nil {
+				// Forget the expectations of p, which the label replaces.
+				in.far.err = furthest(prev, err)
+			}
+		}
+		return t, rest, err
+	}
+}
+
+// Map parses p, then transforms the result with f.
+func Map[T, U any](p Parser[T], f func(T) U) Parser[U] {
+	return func(in Input) (U, Input, error) {
+		t, rest, err := p(in)
+		if err != nil {
+			var zero U
+			return zero, in, err
+		}
+		return f(t), rest, nil
+	}
+}
+
+// MapErr parses p, then transforms the result with f, which can fail.
+// Errors from f are reported at the starting position of p.
+func MapErr[T, U any](p Parser[T], f func(T) (U, error)) Parser[U] {
+	return func(in Input) (U, Input, error) {
+		t, rest, err := p(in)
+		if err != nil {
+			var zero U
+			return zero, in, err
+		}
+		u, err := f(t)
+		if err != nil {
+			return u, in, in.fail(&SyntaxError{Pos: in.pos, Err: err})
+		}
+		return u, rest, nil
+	}
+}
+
+// Seq parses each of ps in order, and returns all the results.
+func Seq[T any](ps ...Parser[T]) Parser[[]T] {
+	return func(in Input) ([]T, Input, error) {
+		out := make([]T, 0, len(ps))
+		rest := in
+		for _, p := range ps {
+			t, r, err := p(rest)
+			if err != nil {
+				return nil, in, err
+			}
+			out = append(out, t)
+			rest = r
+		}
+		return out, rest, nil
+	}
+}
+
+// Seq2 parses pa then pb, and combines the results with f.
+func Seq2[A, B, R any](pa Parser[A], pb Parser[B], f func(A, B) R) Parser[R] {
+	return func(in Input) (R, Input, error) {
+		var zero R
+		a, rest, err := pa(in)
+		if err != nil {
+			return zero, in, err
+		}
+		b, rest, err := pb(rest)
+		if err != nil {
+			return zero, in, err
+		}
+		return f(a, b), rest, nil
+	}
+}
+
+// Seq3 parses pa, pb, then pc, and combines the results with f.
+func Seq3[A, B, C, R any](pa Parser[A], pb Parser[B], pc Parser[C], f func(A, B, C) R) Parser[R] {
+	return func(in Input) (R, Input, error) {
+		var zero R
+		a, rest, err := pa(in)
+		if err != nil {
+			return zero, in, err
+		}
+		b, rest, err := pb(rest)
+		if err != nil {
+			return zero, in, err
+		}
+		c, rest, err := pc(rest)
+		if err != nil {
+			return zero, in, err
+		}
+		return f(a, b, c), rest, nil
+	}
+}
+
+// Left parses pa then pb, and returns the result of pa.
+func Left[A, B any](pa Parser[A], pb Parser[B]) Parser[A] {
+	return Seq2(pa, pb, func(a A, _ B) A { return a })
+}
+
+// Right parses pa then pb, and returns the result of pb.
+func Right[A, B any](pa Parser[A], pb Parser[B]) Parser[B] {
+	return Seq2(pa, pb, func(_ A, b B) B { return b })
+}
+
+// Between parses open, p, then close, and returns the result of p.
+func Between[O, T, C any](open Parser[O], p Parser[T], close Parser[C]) Parser[T] {
+	return Seq3(open, p, close, func(_ O, t T, _ C) T { return t })
+}
+
+// Alt tries each of ps in turn from the same position, and returns the
+// result of the first to succeed. If all fail, the error describes what was
+// expected at the furthest position any of them reached.
+func Alt[T any](ps ...Parser[T]) Parser[T] {
+	return func(in Input) (T, Input, error) {
+		var errs error
+		for _, p := range ps {
+			t, rest, err := p(in)
+			if err == nil {
+				return t, rest, nil
+			}
+			errs = furthest(errs, err)
+		}
+		var zero T
+		return zero, in, errs
+	}
+}
+
+// Optional parses p, or if p fails without consuming input, returns def.
+func Optional[T any](p Parser[T], def T) Parser[T] {
+	return func(in Input) (T, Input, error) {
+		t, rest, err := p(in)
+		if err != nil {
+			if se, ok := err.(*SyntaxError); ok && se.Pos.Offset > in.pos.Offset {
+				return t, in, err
+			}
+			return def, in, nil
+		}
+		return t, rest, nil
+	}
+}
+
+// Many parses p zero or more times. It stops at the first failure of p that
+// does not consume input (a failure that got further is reported as an
+// error, since it most likely describes a real problem).
+func Many[T any](p Parser[T]) Parser[[]T] {
+	return func(in Input) ([]T, Input, error) {
+		var out []T
+		rest := in
+		for {
+			t, r, err := p(rest)
+			if err != nil {
+				if se, ok := err.(*SyntaxError); ok && se.Pos.Offset > rest.pos.Offset {
+					return nil, in, err
+				}
+				return out, rest, nil
+			}
+			if r.pos.Offset == rest.pos.Offset {
+				// p succeeded without consuming anything; avoid looping forever.
+				return append(out, t), r, nil
+			}
+			out = append(out, t)
+			rest = r
+		}
+	}
+}
+
+// Many1 parses p one or more times.
+func Many1[T any](p Parser[T]) Parser[[]T] {
+	return Seq2(p, Many(p), func(t T, ts []T) []T { return append([]T{t}, ts...) })
+}
+
+// SepBy parses zero or more p separated by sep.
+func SepBy[T, S any](p Parser[T], sep Parser[S]) Parser[[]T] {
+	return Optional(SepBy1(p, sep), []T{})
+}
+
+// SepBy1 parses one or more p separated by sep.
+func SepBy1[T, S any](p Parser[T], sep Parser[S]) Parser[[]T] {
+	return Seq2(p, Many(Right(sep, p)), func(t T, ts []T) []T { return append([]T{t}, ts...) })
+}
+
+// Lazy returns a parser that calls *p when run. This allows recursive
+// grammars, where p is assigned after Lazy is called.
+func Lazy[T any](p *Parser[T]) Parser[T] {
+	return func(in Input) (T, Input, error) { return (*p)(in) }
+}
@@ -0,0 +1,129 @@
+/*
+   Copyright 2026 Josh Deprez
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+	   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package parse
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNestedLists(t *testing.T) {
+	var list Parser[any]
+	elem := Alt(Map(Int, func(n int) any { return n }), Lazy(&list))
+	list = Map(Between(Lit("["), SepBy(elem, Lit(",")), Lit("]")), func(xs []any) any { return xs })
+
+	got, err := Run(list, "[1,[2,-3],[],[[4]]]")
+	if err != nil {
+		t.Fatalf("Run(list) error = %v", err)
+	}
+	want := []any{1, []any{2, -3}, []any{}, []any{[]any{4}}}
+	if diff := cmp.Diff(got, any(want)); diff != "" {
+		t.Errorf("Run(list) diff (-got +want):\n%s", diff)
+	}
+
+	tests := []struct {
+		input, wantErr string
+	}{
+		{input: "[1,2", wantErr: `expected one of ",", "]" at 1:5`},
+		{input: "[1,]", wantErr: `expected one of integer, "[" at 1:4`},
+		{input: "[1]x", wantErr: `expected end of input at 1:4`},
+		{input: "[1,[2,x]]", wantErr: `expected one of integer, "[" at 1:7`},
+	}
+	for _, test := range tests {
+		_, err := Run(list, test.input)
+		if err == nil || err.Error() != test.wantErr {
+			t.Errorf("Run(list, %q) error = %v, want %q", test.input, err, test.wantErr)
+		}
+	}
+}
+
+func TestExpression(t *testing.T) {
+	// expr := term (('+' | '-') term)*
+	// term := factor (('*' | '/') factor)*
+	// factor := int | '(' expr ')'
+	type opTerm struct {
+		op string
+		n  int
+	}
+	fold := func(first int, rest []opTerm) int {
+		for _, ot := range rest {
+			switch ot.op {
+			case "+":
+				first += ot.n
+			case "-":
+				first -= ot.n
+			case "*":
+				first *= ot.n
+			case "/":
+				first /= ot.n
+			}
+		}
+		return first
+	}
+	sym := func(s string) Parser[string] { return Token(Lit(s)) }
+	binary := func(operand Parser[int], ops ...string) Parser[int] {
+		opParsers := make([]Parser[string], len(ops))
+		for i, o := range ops {
+			opParsers[i] = sym(o)
+		}
+		tail := Many(Seq2(Alt(opParsers...), operand, func(op string, n int) opTerm { return opTerm{op, n} }))
+		return Seq2(operand, tail, fold)
+	}
+
+	var expr Parser[int]
+	factor := Alt(Token(Int), Between(sym("("), Lazy(&expr), sym(")")))
+	term := binary(factor, "*", "/")
+	expr = binary(term, "+", "-")
+
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{input: "1 + 2 * 3", want: 7},
+		{input: "(1 + 2) * 3", want: 9},
+		{input: "10 - 4 - 3", want: 3},
+		{input: "2 * (3 + 4) * 5 - 6 / 2", want: 67},
+	}
+	for _, test := range tests {
+		got, err := Run(Right(Spaces, expr), test.input)
+		if err != nil {
+			t.Errorf("Run(expr, %q) error = %v", test.input, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("Run(expr, %q) = %d, want %d", test.input, got, test.want)
+		}
+	}
+}
+
+func TestLabel(t *testing.T) {
+	ident := Label("identifier", While("letter", func(r rune) bool { return 'a' <= r && r <= 'z' }))
+	assign := Seq3(ident, Lit("="), Int, func(name string, _ string, n int) map[string]int {
+		return map[string]int{name: n}
+	})
+	if _, err := Run(assign, "=3"); err == nil || err.Error() != "expected identifier at 1:1" {
+		t.Errorf("Run(assign, %q) error = %v, want %q", "=3", err, "expected identifier at 1:1")
+	}
+	got, err := Run(assign, "abc=-3")
+	if err != nil {
+		t.Fatalf("Run(assign) error = %v", err)
+	}
+	if diff := cmp.Diff(got, map[string]int{"abc": -3}); diff != "" {
+		t.Errorf("Run(assign) diff (-got +want):\n%s", diff)
+	}
+}
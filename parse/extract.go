@@ -0,0 +1,235 @@
+/*
+   Copyright 2026 Josh Deprez
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+	   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package parse
+
+import (
+	"fmt"
+	"iter"
+	"strconv"
+)
+
+// The All* functions extract every number embedded in arbitrary text,
+// ignoring everything else. For example, AllInts("x=-12, y=7") returns
+// []int{-12, 7}.
+//
+// A '-' or '+' is treated as a sign when it immediately precedes a digit and
+// does not immediately follow a letter or digit. So "x=-3" contains -3, but
+// both "3-4" and "a-4" contain 4 (not -4). Runs of digits are taken greedily,
+// so "12ab34" contains 12 and 34 (in decimal), or 0x12ab34 (in hex).
+//
+// The *Seq forms yield the numbers one at a time without allocating. If a
+// number can't be represented (e.g. it overflows), they yield the zero value
+// and a *strconv.NumError, then continue with the rest of the text. The slice
+// forms stop at the first such error.
+
+// AllInts returns all the signed decimal integers in s.
+func AllInts(s string) ([]int, error) { return collect(AllIntsSeq(s)) }
+
+// AllIntsSeq yields all the signed decimal integers in s.
+func AllIntsSeq(s string) iter.Seq2[int, error] {
+	return func(yield func(int, error) bool) {
+		for tok := range numbers(s, 10, true, false) {
+			n, err := strconv.ParseInt(tok, 10, strconv.IntSize)
+			if !yield(int(n), err) {
+				return
+			}
+		}
+	}
+}
+
+// AllUints returns all the unsigned decimal integers in s. Signs are ignored,
+// so AllUints("x=-12") returns []uint{12}.
+func AllUints(s string) ([]uint, error) { return collect(AllUintsSeq(s)) }
+
+// AllUintsSeq yields all the unsigned decimal integers in s.
+func AllUintsSeq(s string) iter.Seq2[uint, error] {
+	return func(yield func(uint, error) bool) {
+		for tok := range numbers(s, 10, false, false) {
+			n, err := strconv.ParseUint(tok, 10, strconv.IntSize)
+			if !yield(uint(n), err) {
+				return
+			}
+		}
+	}
+}
+
+// AllInt64s returns all the signed decimal integers in s, as int64s.
+func AllInt64s(s string) ([]int64, error) { return collect(AllInt64sSeq(s)) }
+
+// AllInt64sSeq yields all the signed decimal integers in s, as int64s.
+func AllInt64sSeq(s string) iter.Seq2[int64, error] {
+	return func(yield func(int64, error) bool) {
+		for tok := range numbers(s, 10, true, false) {
+			if !yield(strconv.ParseInt(tok, 10, 64)) {
+				return
+			}
+		}
+	}
+}
+
+// AllHex returns all the unsigned hexadecimal integers in s. Each is a run of
+// hex digits (of either case), optionally prefixed with "0x" or "0X".
+func AllHex(s string) ([]uint64, error) { return collect(AllHexSeq(s)) }
+
+// AllHexSeq yields all the unsigned hexadecimal integers in s.
+func AllHexSeq(s string) iter.Seq2[uint64, error] {
+	return func(yield func(uint64, error) bool) {
+		for tok := range numbers(s, 16, false, false) {
+			if !yield(strconv.ParseUint(tok, 16, 64)) {
+				return
+			}
+		}
+	}
+}
+
+// AllBinary returns all the unsigned binary integers in s. Each is a run of
+// 0s and 1s, optionally prefixed with "0b" or "0B".
+func AllBinary(s string) ([]uint64, error) { return collect(AllBinarySeq(s)) }
+
+// AllBinarySeq yields all the unsigned binary integers in s.
+func AllBinarySeq(s string) iter.Seq2[uint64, error] {
+	return func(yield func(uint64, error) bool) {
+		for tok := range numbers(s, 2, false, false) {
+			if !yield(strconv.ParseUint(tok, 2, 64)) {
+				return
+			}
+		}
+	}
+}
+
+// AllFloats returns all the signed decimal floating-point numbers in s. Each
+// has at least one digit before an optional fractional part, and an optional
+// exponent (e.g. "3", "-2.5", "6.02e23").
+func AllFloats(s string) ([]float64, error) { return collect(AllFloatsSeq(s)) }
+
+// AllFloatsSeq yields all the signed decimal floating-point numbers in s.
+func AllFloatsSeq(s string) iter.Seq2[float64, error] {
+	return func(yield func(float64, error) bool) {
+		for tok := range numbers(s, 10, true, true) {
+			if !yield(strconv.ParseFloat(tok, 64)) {
+				return
+			}
+		}
+	}
+}
+
+// DigitsBase converts a string of digits in the given base (2 to 36) into
+// `[]int`, where each element is the value of a digit. Digits above 9 are
+// letters of either case, so DigitsBase("fF", 16) returns []int{15, 15}.
+func DigitsBase(s string, base int) ([]int, error) {
+	if base < 2 || base > 36 {
+		return nil, fmt.Errorf("invalid base %d", base)
+	}
+	r := make([]int, 0, len(s))
+	for i, c := range s {
+		d := digitVal(c)
+		if d >= base {
+			return nil, fmt.Errorf("rune %c at pos %d is not a digit %s", c, i, digitRange(base))
+		}
+		r = append(r, d)
+	}
+	return r, nil
+}
+
+// digitVal returns the value of c as a digit, or 36 if c is not a digit in
+// any base up to 36.
+func digitVal(c rune) int {
+	switch {
+	case '0' <= c && c <= '9':
+		return int(c - '0')
+	case 'a' <= c && c <= 'z':
+		return int(c-'a') + 10
+	case 'A' <= c && c <= 'Z':
+		return int(c-'A') + 10
+	}
+	return 36
+}
+
+// digitRange describes the valid digits in base, e.g. "[0-9a-f]".
+func digitRange(base int) string {
+	if base <= 10 {
+		return fmt.Sprintf("[0-%d]", base-1)
+	}
+	return fmt.Sprintf("[0-9a-%c]", 'a'+base-11)
+}
+
+// numbers yields the substrings of s that are numbers in base, as described
+// above. If signed, tokens may begin with a sign. If float, tokens may have
+// a fractional part and exponent (base must be 10). Any "0x" or "0b" prefix
+// (for base 16 or 2) is not included in the token.
+func numbers(s string, base int, signed, float bool) iter.Seq[string] {
+	isDigit := func(i int) bool {
+		return i < len(s) && digitVal(rune(s[i])) < base
+	}
+	digitsFrom := func(i int) int {
+		for isDigit(i) {
+			i++
+		}
+		return i
+	}
+	return func(yield func(string) bool) {
+		for i := 0; i < len(s); {
+			start := i
+			if signed && (s[i] == '-' || s[i] == '+') && isDigit(i+1) &&
+				(i == 0 || digitVal(rune(s[i-1])) == 36) {
+				i++
+			} else if !isDigit(i) {
+				i++
+				continue
+			}
+
+			// Skip a base prefix, if followed by at least one digit.
+			if s[i] == '0' && i+2 < len(s) && isDigit(i+2) &&
+				((base == 16 && (s[i+1] == 'x' || s[i+1] == 'X')) ||
+					(base == 2 && (s[i+1] == 'b' || s[i+1] == 'B'))) {
+				i += 2
+				start = i
+			}
+
+			i = digitsFrom(i)
+			if float {
+				if i+1 < len(s) && s[i] == '.' && isDigit(i+1) {
+					i = digitsFrom(i + 1)
+				}
+				if i+1 < len(s) && (s[i] == 'e' || s[i] == 'E') {
+					j := i + 1
+					if s[j] == '-' || s[j] == '+' {
+						j++
+					}
+					if isDigit(j) {
+						i = digitsFrom(j)
+					}
+				}
+			}
+			if !yield(s[start:i]) {
+				return
+			}
+		}
+	}
+}
+
+// collect gathers the values from seq, stopping at the first error.
+func collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var r []T
+	for t, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		r = append(r, t)
+	}
+	return r, nil
+}
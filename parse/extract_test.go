@@ -0,0 +1,165 @@
+/*
+   Copyright 2026 Josh Deprez
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+	   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package parse
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAllInts(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []int
+	}{
+		{"", nil},
+		{"no numbers here", nil},
+		{"x=-12, y=7", []int{-12, 7}},
+		{"Sensor at x=2, y=-18: closest beacon is at x=-2, y=+15", []int{2, -18, -2, 15}},
+		{"3-4 a-5 -6", []int{3, 4, 5, -6}},
+		{"--7 - 8", []int{-7, 8}},
+		{"12ab34", []int{12, 34}},
+		{"move 1 from 2 to 3", []int{1, 2, 3}},
+	}
+	for _, test := range tests {
+		got, err := AllInts(test.input)
+		if err != nil {
+			t.Errorf("AllInts(%q) error = %v", test.input, err)
+			continue
+		}
+		if diff := cmp.Diff(got, test.want); diff != "" {
+			t.Errorf("AllInts(%q) diff (-got +want):\n%s", test.input, diff)
+		}
+	}
+}
+
+func TestAllIntsVariants(t *testing.T) {
+	const input = "x=-12, y=0x7f; mask=0b1011 speed=6.02e23 depth=-.5"
+
+	uints, err := AllUints(input)
+	if err != nil {
+		t.Fatalf("AllUints(%q) error = %v", input, err)
+	}
+	if diff := cmp.Diff(uints, []uint{12, 0, 7, 0, 1011, 6, 2, 23, 5}); diff != "" {
+		t.Errorf("AllUints(%q) diff (-got +want):\n%s", input, diff)
+	}
+
+	int64s, err := AllInt64s(input)
+	if err != nil {
+		t.Fatalf("AllInt64s(%q) error = %v", input, err)
+	}
+	if diff := cmp.Diff(int64s, []int64{-12, 0, 7, 0, 1011, 6, 2, 23, 5}); diff != "" {
+		t.Errorf("AllInt64s(%q) diff (-got +want):\n%s", input, diff)
+	}
+
+	hex, err := AllHex("#ff00A0, 0x1F and dead beef")
+	if err != nil {
+		t.Fatalf("AllHex() error = %v", err)
+	}
+	if diff := cmp.Diff(hex, []uint64{0xff00a0, 0x1f, 0xa, 0xd, 0xdead, 0xbeef}); diff != "" {
+		t.Errorf("AllHex() diff (-got +want):\n%s", diff)
+	}
+
+	bin, err := AllBinary("0b1011, 110 and 2")
+	if err != nil {
+		t.Fatalf("AllBinary() error = %v", err)
+	}
+	if diff := cmp.Diff(bin, []uint64{0b1011, 0b110}); diff != "" {
+		t.Errorf("AllBinary() diff (-got +want):\n%s", diff)
+	}
+
+	floats, err := AllFloats(input)
+	if err != nil {
+		t.Fatalf("AllFloats(%q) error = %v", input, err)
+	}
+	if diff := cmp.Diff(floats, []float64{-12, 0, 7, 0, 1011, 6.02e23, 5}); diff != "" {
+		t.Errorf("AllFloats(%q) diff (-got +want):\n%s", input, diff)
+	}
+}
+
+func TestAllIntsOverflow(t *testing.T) {
+	const input = "1 99999999999999999999 2"
+	if _, err := AllInts(input); !errors.Is(err, strconv.ErrRange) {
+		t.Errorf("AllInts(%q) error = %v, want %v", input, err, strconv.ErrRange)
+	}
+
+	// The Seq form continues past the error.
+	var got []int
+	errs := 0
+	for n, err := range AllIntsSeq(input) {
+		if err != nil {
+			errs++
+			continue
+		}
+		got = append(got, n)
+	}
+	if errs != 1 {
+		t.Errorf("AllIntsSeq(%q) yielded %d errors, want 1", input, errs)
+	}
+	if diff := cmp.Diff(got, []int{1, 2}); diff != "" {
+		t.Errorf("AllIntsSeq(%q) diff (-got +want):\n%s", input, diff)
+	}
+}
+
+func TestAllIntsSeqAllocs(t *testing.T) {
+	const input = "Sensor at x=2, y=-18: closest beacon is at x=-2, y=15"
+	sum := 0
+	allocs := testing.AllocsPerRun(100, func() {
+		for n := range AllIntsSeq(input) {
+			sum += n
+		}
+	})
+	if allocs > 0 {
+		t.Errorf("AllIntsSeq allocated %v times per run, want 0", allocs)
+	}
+}
+
+func TestDigitsBase(t *testing.T) {
+	tests := []struct {
+		input   string
+		base    int
+		want    []int
+		wantErr string
+	}{
+		{input: "0123456789", base: 10, want: []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}},
+		{input: "1011", base: 2, want: []int{1, 0, 1, 1}},
+		{input: "fF09", base: 16, want: []int{15, 15, 0, 9}},
+		{input: "zZ", base: 36, want: []int{35, 35}},
+		{input: "12", base: 2, wantErr: "rune 2 at pos 1 is not a digit [0-1]"},
+		{input: "ag", base: 16, wantErr: "rune g at pos 1 is not a digit [0-9a-f]"},
+		{input: "1", base: 37, wantErr: "invalid base 37"},
+	}
+	for _, test := range tests {
+		got, err := DigitsBase(test.input, test.base)
+		if test.wantErr != "" {
+			if err == nil || err.Error() != test.wantErr {
+				t.Errorf("DigitsBase(%q, %d) error = %v, want %q", test.input, test.base, err, test.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("DigitsBase(%q, %d) error = %v", test.input, test.base, err)
+			continue
+		}
+		if diff := cmp.Diff(got, test.want); diff != "" {
+			t.Errorf("DigitsBase(%q, %d) diff (-got +want):\n%s", test.input, test.base, diff)
+		}
+	}
+}
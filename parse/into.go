@@ -0,0 +1,452 @@
+/*
+   Copyright 2026 Josh Deprez
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+	   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package parse
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Error is an error that occurred at a particular position in the input.
+type Error struct {
+	Col   int    // 1-based column (byte offset + 1) in the input
+	Field string // the struct field being parsed, if any
+	Err   error
+}
+
+func (e *Error) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("col %d: %v", e.Col, e.Err)
+	}
+	return fmt.Sprintf("col %d: field %s: %v", e.Col, e.Field, e.Err)
+}
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// Into parses s into the struct pointed to by v, as described by struct tags
+// on the fields of v. Unexported fields (other than those named _) are
+// ignored.
+//
+// By default, the fields are parsed in order, each from a piece of s:
+//
+//   - `lit:"text"` requires the literal text to appear immediately before
+//     the field. A field named _ with a lit tag matches only the literal.
+//   - The text for a field extends up to the next field's literal, or, if the
+//     next field has no literal, up to the next whitespace (and whitespace
+//     before a field with no literal is skipped). The last field extends to
+//     the end of s.
+//
+// Alternatively, the first field can be a field named _ with a
+// `re:"regexp"` tag. Then the whole of s must match the regular expression
+// (it is implicitly anchored at both ends), and each remaining field is
+// parsed from the corresponding capture group, in order, unless the field has
+// a `group:"name"` or `group:"N"` tag.
+//
+// The text for each field is converted according to the field's type:
+//
+//   - Types implementing encoding.TextUnmarshaler use UnmarshalText.
+//   - Strings are used as-is.
+//   - Integers and floats are parsed with leading and trailing spaces
+//     trimmed. `base:"N"` sets the integer base (the default is 10, and 0
+//     means the base is implied by the prefix, as for strconv.ParseInt).
+//   - `enum:"A B C"` (space-separated) restricts strings to the given set,
+//     or for integer fields, stores the index of the matching string.
+//   - Slices are split using `delim:"text"` (the default is to split on
+//     whitespace), and each element is converted according to the element
+//     type. Nested slices use `delim2` (and `delim3`) for the inner levels.
+//     A []byte with no delim is the bytes of the text.
+//   - Structs (and pointers to structs) are parsed recursively with Into.
+//
+// Errors are reported as *Error, with the column at which parsing failed.
+func Into(s string, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("parse.Into: want non-nil pointer to struct, got %T", v)
+	}
+	return parseStruct(s, 0, rv.Elem())
+}
+
+// Struct parses s into a new value of struct type T, as described for Into.
+// Its signature suits functions like algo.MapOrErr and grid.FromStringsFunc.
+func Struct[T any](s string) (T, error) {
+	var t T
+	err := Into(s, &t)
+	return t, err
+}
+
+// Value parses s into the variable pointed to by v, converting it as Into
+// converts the text for an untagged field.
+func Value(s string, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("parse.Value: want non-nil pointer, got %T", v)
+	}
+	fp := &fieldPlan{base: 10}
+	return fp.setValue(rv.Elem(), s, 0, nil)
+}
+
+// structPlan describes how to parse a struct type.
+type structPlan struct {
+	re     *regexp.Regexp
+	fields []*fieldPlan
+}
+
+// fieldPlan describes how to parse one field.
+type fieldPlan struct {
+	index  []int // nil for _ fields
+	name   string
+	lit    string
+	group  int
+	base   int
+	enum   []string
+	delims []string
+}
+
+var plans sync.Map // reflect.Type -> *structPlan or error
+
+// planFor returns the (cached) plan for struct type t.
+func planFor(t reflect.Type) (*structPlan, error) {
+	if p, ok := plans.Load(t); ok {
+		if err, ok := p.(error); ok {
+			return nil, err
+		}
+		return p.(*structPlan), nil
+	}
+	p, err := makePlan(t)
+	if err != nil {
+		plans.Store(t, err)
+		return nil, err
+	}
+	plans.Store(t, p)
+	return p, nil
+}
+
+func makePlan(t reflect.Type) (*structPlan, error) {
+	sp := new(structPlan)
+	nextGroup := 1
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		blank := sf.Name == "_"
+		if !blank && !sf.IsExported() {
+			continue
+		}
+		bad := func(format string, a ...any) error {
+			return fmt.Errorf("parse: invalid tag on %v.%s: %s", t, sf.Name, fmt.Sprintf(format, a...))
+		}
+
+		if re, ok := sf.Tag.Lookup("re"); ok {
+			if !blank || i != 0 {
+				return nil, bad("re tag is only allowed on the first field, which must be named _")
+			}
+			r, err := regexp.Compile(`^(?:` + re + `)$`)
+			if err != nil {
+				return nil, bad("%v", err)
+			}
+			sp.re = r
+			continue
+		}
+
+		fp := &fieldPlan{
+			name: sf.Name,
+			lit:  sf.Tag.Get("lit"),
+			base: 10,
+		}
+		if !blank {
+			fp.index = sf.Index
+		}
+		if b, ok := sf.Tag.Lookup("base"); ok {
+			n, err := strconv.Atoi(b)
+			if err != nil || n == 1 || n < 0 || n > 36 {
+				return nil, bad("base %q", b)
+			}
+			fp.base = n
+		}
+		if e, ok := sf.Tag.Lookup("enum"); ok {
+			fp.enum = strings.Fields(e)
+		}
+		for _, key := range []string{"delim", "delim2", "delim3"} {
+			d, ok := sf.Tag.Lookup(key)
+			if !ok {
+				break
+			}
+			fp.delims = append(fp.delims, d)
+		}
+
+		if sp.re != nil && !blank {
+			fp.group = nextGroup
+			nextGroup++
+			if g, ok := sf.Tag.Lookup("group"); ok {
+				if n, err := strconv.Atoi(g); err == nil {
+					if n < 0 {
+						return nil, bad("group %d", n)
+					}
+					fp.group = n
+				} else if fp.group = sp.re.SubexpIndex(g); fp.group < 0 {
+					return nil, bad("no capture group named %q", g)
+				}
+			}
+			if fp.group > sp.re.NumSubexp() {
+				return nil, bad("regexp has only %d capture groups", sp.re.NumSubexp())
+			}
+		}
+		if sp.re != nil && blank {
+			// Literals have no meaning in regexp mode.
+			continue
+		}
+		sp.fields = append(sp.fields, fp)
+	}
+	return sp, nil
+}
+
+// parseStruct parses s (which begins at offset off in the original input)
+// into the struct value v.
+func parseStruct(s string, off int, v reflect.Value) error {
+	sp, err := planFor(v.Type())
+	if err != nil {
+		return err
+	}
+
+	if sp.re != nil {
+		m := sp.re.FindStringSubmatchIndex(s)
+		if m == nil {
+			return &Error{Col: off + 1, Err: fmt.Errorf("%q does not match %v", s, sp.re)}
+		}
+		for _, fp := range sp.fields {
+			start, end := m[2*fp.group], m[2*fp.group+1]
+			if start < 0 {
+				// Optional group did not participate; leave the zero value.
+				continue
+			}
+			if err := fp.set(v.FieldByIndex(fp.index), s[start:end], off+start); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	pos := 0
+	for i, fp := range sp.fields {
+		if fp.lit == "" && i > 0 {
+			pos += len(s[pos:]) - len(strings.TrimLeftFunc(s[pos:], unicode.IsSpace))
+		}
+		if !strings.HasPrefix(s[pos:], fp.lit) {
+			return &Error{Col: off + pos + 1, Field: fp.fieldName(), Err: fmt.Errorf("expected %q", fp.lit)}
+		}
+		pos += len(fp.lit)
+		if fp.index == nil {
+			continue
+		}
+
+		end := len(s)
+		if i+1 < len(sp.fields) {
+			if next := sp.fields[i+1].lit; next != "" {
+				k := strings.Index(s[pos:], next)
+				if k < 0 {
+					return &Error{Col: off + pos + 1, Field: fp.name, Err: fmt.Errorf("expected %q after field", next)}
+				}
+				end = pos + k
+			} else if k := strings.IndexFunc(s[pos:], unicode.IsSpace); k >= 0 {
+				end = pos + k
+			}
+		}
+		if err := fp.set(v.FieldByIndex(fp.index), s[pos:end], off+pos); err != nil {
+			return err
+		}
+		pos = end
+	}
+	if pos != len(s) {
+		return &Error{Col: off + pos + 1, Err: fmt.Errorf("unexpected trailing text %q", s[pos:])}
+	}
+	return nil
+}
+
+// fieldName returns the name of the field for error messages.
+func (fp *fieldPlan) fieldName() string {
+	if fp.index == nil {
+		return ""
+	}
+	return fp.name
+}
+
+// set parses text (which begins at offset off in the original input) into
+// the field value v.
+func (fp *fieldPlan) set(v reflect.Value, text string, off int) error {
+	err := fp.setValue(v, text, off, fp.delims)
+	if err == nil {
+		return nil
+	}
+	var pe *Error
+	if errors.As(err, &pe) {
+		return err
+	}
+	return &Error{Col: off + 1, Field: fp.name, Err: err}
+}
+
+var textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+func (fp *fieldPlan) setValue(v reflect.Value, text string, off int, delims []string) error {
+	if v.CanAddr() && v.Addr().Type().Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(text))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		if fp.enum != nil && fp.enumIndex(text) < 0 {
+			return fmt.Errorf("%q is not one of %q", text, fp.enum)
+		}
+		v.SetString(text)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if fp.enum != nil {
+			i := fp.enumIndex(text)
+			if i < 0 {
+				return fmt.Errorf("%q is not one of %q", text, fp.enum)
+			}
+			v.SetInt(int64(i))
+			return nil
+		}
+		n, err := strconv.ParseInt(strings.TrimSpace(text), fp.base, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if fp.enum != nil {
+			i := fp.enumIndex(text)
+			if i < 0 {
+				return fmt.Errorf("%q is not one of %q", text, fp.enum)
+			}
+			v.SetUint(uint64(i))
+			return nil
+		}
+		n, err := strconv.ParseUint(strings.TrimSpace(text), fp.base, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(strings.TrimSpace(text), v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(text))
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+
+	case reflect.Slice:
+		if len(delims) == 0 && v.Type().Elem().Kind() == reflect.Uint8 {
+			v.SetBytes([]byte(text))
+			return nil
+		}
+		var delim string
+		if len(delims) > 0 {
+			delim, delims = delims[0], delims[1:]
+		}
+		parts := splitOffsets(text, delim)
+		sl := reflect.MakeSlice(v.Type(), len(parts), len(parts))
+		for i, p := range parts {
+			if err := fp.setValue(sl.Index(i), text[p[0]:p[1]], off+p[0], delims); err != nil {
+				var pe *Error
+				if errors.As(err, &pe) {
+					return err
+				}
+				return &Error{Col: off + p[0] + 1, Field: fmt.Sprintf("%s[%d]", fp.name, i), Err: err}
+			}
+		}
+		v.Set(sl)
+
+	case reflect.Struct:
+		return parseStruct(text, off, v)
+
+	case reflect.Pointer:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return fp.setValue(v.Elem(), text, off, delims)
+
+	default:
+		return fmt.Errorf("unsupported type %v", v.Type())
+	}
+	return nil
+}
+
+// enumIndex returns the index of text in fp.enum, or -1.
+func (fp *fieldPlan) enumIndex(text string) int {
+	for i, e := range fp.enum {
+		if e == text {
+			return i
+		}
+	}
+	return -1
+}
+
+// splitOffsets splits s by delim (or by whitespace if delim is empty),
+// returning the start and end offsets of each part. Parts split by delim have
+// surrounding whitespace trimmed. An empty (or all-whitespace) s has no parts.
+func splitOffsets(s, delim string) [][2]int {
+	var out [][2]int
+	if delim == "" {
+		start := -1
+		for i, r := range s {
+			switch {
+			case unicode.IsSpace(r) && start >= 0:
+				out = append(out, [2]int{start, i})
+				start = -1
+			case !unicode.IsSpace(r) && start < 0:
+				start = i
+			}
+		}
+		if start >= 0 {
+			out = append(out, [2]int{start, len(s)})
+		}
+		return out
+	}
+
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	pos := 0
+	for {
+		k := strings.Index(s[pos:], delim)
+		end := len(s)
+		if k >= 0 {
+			end = pos + k
+		}
+		part := s[pos:end]
+		lead := len(part) - len(strings.TrimLeftFunc(part, unicode.IsSpace))
+		trail := len(part) - len(strings.TrimRightFunc(part, unicode.IsSpace))
+		out = append(out, [2]int{pos + lead, max(pos+lead, end-trail)})
+		if k < 0 {
+			return out
+		}
+		pos = end + len(delim)
+	}
+}
@@ -0,0 +1,184 @@
+/*
+   Copyright 2026 Josh Deprez
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+	   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package parse
+
+import (
+	"errors"
+	"net/netip"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestIntoLiterals(t *testing.T) {
+	type sensor struct {
+		SX int `lit:"Sensor at x="`
+		SY int `lit:", y="`
+		BX int `lit:": closest beacon is at x="`
+		BY int `lit:", y="`
+	}
+	got, err := Struct[sensor]("Sensor at x=2, y=-18: closest beacon is at x=-2, y=15")
+	if err != nil {
+		t.Fatalf("Struct() error = %v", err)
+	}
+	if want := (sensor{2, -18, -2, 15}); got != want {
+		t.Errorf("Struct() = %+v, want %+v", got, want)
+	}
+}
+
+func TestIntoWhitespace(t *testing.T) {
+	type move struct {
+		_     struct{} `lit:"move"`
+		Count int
+		_     struct{} `lit:"from"`
+		From  int
+		_     struct{} `lit:"to"`
+		To    int
+	}
+	got, err := Struct[move]("move 13 from 2 to 9")
+	if err != nil {
+		t.Fatalf("Struct() error = %v", err)
+	}
+	if want := (move{Count: 13, From: 2, To: 9}); got != want {
+		t.Errorf("Struct() = %+v, want %+v", got, want)
+	}
+}
+
+func TestIntoRegexp(t *testing.T) {
+	type policy struct {
+		_        struct{} `re:"^(\\d+)-(\\d+) (?P<letter>\\w): (\\w+)$"`
+		Min, Max int
+		Letter   string `group:"letter"`
+		Password []byte `group:"4"`
+	}
+	got, err := Struct[policy]("1-3 a: abcde")
+	if err != nil {
+		t.Fatalf("Struct() error = %v", err)
+	}
+	want := policy{Min: 1, Max: 3, Letter: "a", Password: []byte("abcde")}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Struct() diff (-got +want):\n%s", diff)
+	}
+}
+
+func TestIntoNested(t *testing.T) {
+	type point struct {
+		X int
+		Y int `lit:","`
+	}
+	type record struct {
+		Name   string
+		Dir    int        `enum:"N E S W"`
+		Colour string     `enum:"red green blue"`
+		Mask   uint16     `base:"16"`
+		Points []point    `delim:" -> "`
+		Groups [][]int    `lit:" |" delim:";" delim2:","`
+		Addr   netip.Addr `lit:" @ "`
+	}
+	got, err := Struct[record]("widget S green ff0f 1,2 -> 3,4 | 1,2,3; 4 ;5,6 @ 10.0.0.1")
+	if err != nil {
+		t.Fatalf("Struct() error = %v", err)
+	}
+	want := record{
+		Name:   "widget",
+		Dir:    2,
+		Colour: "green",
+		Mask:   0xff0f,
+		Points: []point{{1, 2}, {3, 4}},
+		Groups: [][]int{{1, 2, 3}, {4}, {5, 6}},
+		Addr:   netip.MustParseAddr("10.0.0.1"),
+	}
+	if diff := cmp.Diff(got, want, cmp.Comparer(func(a, b netip.Addr) bool { return a == b })); diff != "" {
+		t.Errorf("Struct() diff (-got +want):\n%s", diff)
+	}
+}
+
+func TestIntoErrors(t *testing.T) {
+	type point struct {
+		X int `lit:"x="`
+		Y int `lit:", y="`
+	}
+	type line struct {
+		Points []point `delim:" to "`
+	}
+	type coloured struct {
+		Colour string `enum:"red green blue"`
+	}
+
+	tests := []struct {
+		input     string
+		into      any
+		wantCol   int
+		wantField string
+		wantErr   error
+	}{
+		{input: "x=1, y=2 to x=3, y=four", into: new(line), wantCol: 20, wantField: "Y", wantErr: strconv.ErrSyntax},
+		{input: "x=1; y=2", into: new(point), wantCol: 3, wantField: "X"},
+		{input: "q=1, y=2", into: new(point), wantCol: 1, wantField: "X"},
+		{input: "x=1, y=2!", into: new(point), wantCol: 8, wantField: "Y", wantErr: strconv.ErrSyntax},
+		{input: "purple", into: new(coloured), wantCol: 1, wantField: "Colour"},
+	}
+	for _, test := range tests {
+		err := Into(test.input, test.into)
+		var pe *Error
+		if !errors.As(err, &pe) {
+			t.Errorf("Into(%q) error = %v, want *Error", test.input, err)
+			continue
+		}
+		if pe.Col != test.wantCol || pe.Field != test.wantField {
+			t.Errorf("Into(%q) error = %v, want col %d field %q", test.input, err, test.wantCol, test.wantField)
+		}
+		if test.wantErr != nil && !errors.Is(err, test.wantErr) {
+			t.Errorf("Into(%q) error = %v, want %v", test.input, err, test.wantErr)
+		}
+	}
+
+	if err := Into("1", new(int)); err == nil {
+		t.Errorf("Into(non-struct) error = nil, want error")
+	}
+
+	type negGroup struct {
+		_ struct{} `re:"(\\d+)"`
+		N int      `group:"-1"`
+	}
+	if err := Into("1", new(negGroup)); err == nil {
+		t.Errorf("Into(group -1) error = nil, want error")
+	}
+
+	type digits struct {
+		_ struct{} `re:"(\\d+)"`
+		N int
+	}
+	if err := Into("x12y", new(digits)); err == nil {
+		t.Errorf("Into(partial match) error = nil, want error")
+	}
+}
+
+func TestValue(t *testing.T) {
+	var n int
+	if err := Value(" -42 ", &n); err != nil || n != -42 {
+		t.Errorf("Value(-42) = %d, %v, want -42, nil", n, err)
+	}
+	var p *netip.Addr
+	if err := Value("::1", &p); err != nil || p == nil || *p != netip.IPv6Loopback() {
+		t.Errorf("Value(::1) = %v, %v, want ::1, nil", p, err)
+	}
+	if err := Value("1", n); err == nil {
+		t.Errorf("Value(non-pointer) error = nil, want error")
+	}
+}
@@ -18,25 +18,18 @@
 package parse
 
 import (
-	"fmt"
 	"strconv"
 	"strings"
 )
 
 // Digits converts a string of decimal digits (0-9) into `[]int`, where
-// each element is the value of a digit.
+// each element is the value of a digit. See DigitsBase for other bases.
 func Digits(s string) ([]int, error) {
-	r := make([]int, len(s))
-	for i, d := range s {
-		if d < '0' || d > '9' {
-			return nil, fmt.Errorf("rune %c at pos %d is not a digit [0-9]", d, i)
-		}
-		r[i] = int(d - '0')
-	}
-	return r, nil
+	return DigitsBase(s, 10)
 }
 
-// Ints converts whitespace-separated ints into `[]int`.
+// Ints converts whitespace-separated ints into `[]int`. To extract ints from
+// text containing other things, use AllInts.
 func Ints(s string) ([]int, error) {
 	fs := strings.Fields(s)
 	r := make([]int, len(fs))
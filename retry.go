@@ -2,7 +2,6 @@ package exp
 
 import (
 	"context"
-	"math/rand"
 	"time"
 )
 
@@ -30,38 +29,10 @@ import (
 //
 // To ensure resources (internal goroutines) are cleaned up, cancel the context.
 // The channel is closed when the internal goroutine returns.
+//
+// RetryChan(ctx, count, initial, grow) is equivalent to
+// Backoff{Count: count, Initial: initial, Grow: grow}.RetryChan(ctx), except
+// that at least one time is always sent.
 func RetryChan(ctx context.Context, count int, initial time.Duration, grow float64) <-chan time.Time {
-	ch := make(chan time.Time)
-	go func() {
-		defer close(ch)
-
-		select {
-		case ch <- time.Now():
-		case <-ctx.Done():
-			return
-		}
-
-		df := float64(initial)
-		for n := 1; n < count; n++ {
-			d := time.Duration(rand.Int63n(int64(df)))
-			timer := time.NewTimer(d)
-			select {
-			case t := <-timer.C:
-				select {
-				case ch <- t:
-
-				case <-ctx.Done():
-					timer.Stop()
-					return
-				}
-
-			case <-ctx.Done():
-				timer.Stop()
-				return
-			}
-
-			df *= grow
-		}
-	}()
-	return ch
+	return Backoff{Count: max(count, 1), Initial: initial, Grow: grow}.RetryChan(ctx)
 }
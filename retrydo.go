@@ -0,0 +1,129 @@
+package exp
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// DoOption configures Do.
+type DoOption func(*doConfig)
+
+type doConfig struct {
+	retryable func(error) bool
+	onRetry   func(attempt int, err error, delay time.Duration)
+}
+
+// WithRetryable sets the function used by Do to classify errors. If it
+// returns false, Do stops retrying. By default, all errors are retryable
+// (except those marked with Permanent, which are never retried).
+func WithRetryable(f func(error) bool) DoOption {
+	return func(c *doConfig) { c.retryable = f }
+}
+
+// WithOnRetry sets a function called by Do after each failed attempt that
+// will be retried, with the attempt number (starting at 1), the error, and the
+// wait before the next attempt.
+func WithOnRetry(f func(attempt int, err error, delay time.Duration)) DoOption {
+	return func(c *doConfig) { c.onRetry = f }
+}
+
+// Do calls op until it returns nil, waiting between attempts according to the
+// policy b (as for b.Retry). It returns nil as soon as op succeeds. Otherwise,
+// Do stops when the attempts or budget are exhausted, the context is done, or
+// op returns an error that is not retryable (see Permanent and
+// WithRetryable), and returns all the errors from each attempt joined with
+// errors.Join (plus the context's error, if that was why it stopped).
+//
+// If an error carries a retry-after hint (see RetryAfter), the wait before the
+// next attempt is at least as long as the hint.
+func Do(ctx context.Context, b Backoff, op func(context.Context) error, opts ...DoOption) error {
+	var c doConfig
+	for _, o := range opts {
+		o(&c)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var (
+		errs    []error
+		attempt int
+		lastErr error
+	)
+	adjust := func(d time.Duration) time.Duration {
+		var ra interface{ RetryAfter() time.Duration }
+		if errors.As(lastErr, &ra) {
+			d = max(d, ra.RetryAfter())
+		}
+		return d
+	}
+	onWait := func(d time.Duration) {
+		if c.onRetry != nil {
+			c.onRetry(attempt, lastErr, d)
+		}
+	}
+	for range b.retry(ctx, adjust, onWait) {
+		attempt++
+		err := op(ctx)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, err)
+		if !c.isRetryable(err) {
+			return errors.Join(errs...)
+		}
+		lastErr = err
+	}
+	if err := ctx.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// isRetryable reports whether err should be retried by Do.
+func (c *doConfig) isRetryable(err error) bool {
+	var perm *PermanentError
+	if errors.As(err, &perm) {
+		return false
+	}
+	return c.retryable == nil || c.retryable(err)
+}
+
+// PermanentError wraps an error to tell Do not to retry.
+type PermanentError struct {
+	Err error
+}
+
+// Permanent wraps err in a PermanentError. If err is nil, it returns nil.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &PermanentError{Err: err}
+}
+
+func (e *PermanentError) Error() string { return e.Err.Error() }
+func (e *PermanentError) Unwrap() error { return e.Err }
+
+// RetryAfterError wraps an error with a hint (e.g. from a server) of how long
+// to wait before retrying. Do honours any error in the chain that has a
+// RetryAfter() time.Duration method, not only RetryAfterError.
+type RetryAfterError struct {
+	Err   error
+	After time.Duration
+}
+
+// RetryAfter wraps err in a RetryAfterError. If err is nil, it returns nil.
+func RetryAfter(err error, after time.Duration) error {
+	if err == nil {
+		return nil
+	}
+	return &RetryAfterError{Err: err, After: after}
+}
+
+func (e *RetryAfterError) Error() string { return e.Err.Error() }
+func (e *RetryAfterError) Unwrap() error { return e.Err }
+
+// RetryAfter returns e.After.
+func (e *RetryAfterError) RetryAfter() time.Duration { return e.After }
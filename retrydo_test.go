@@ -0,0 +1,141 @@
+package exp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func ExampleDo() {
+	ctx := context.Background()
+	policy := Backoff{
+		Count:   5,
+		Initial: time.Millisecond,
+		Grow:    2,
+	}
+	onRetry := func(attempt int, err error, _ time.Duration) {
+		fmt.Printf("attempt %d failed: %v\n", attempt, err)
+	}
+
+	calls := 0
+	err := Do(ctx, policy, func(context.Context) error {
+		calls++
+		if calls < 3 {
+			return fmt.Errorf("flaky %d", calls)
+		}
+		return nil
+	}, WithOnRetry(onRetry))
+	fmt.Printf("calls: %d, err: %v\n", calls, err)
+
+	// Output:
+	// attempt 1 failed: flaky 1
+	// attempt 2 failed: flaky 2
+	// calls: 3, err: <nil>
+}
+
+func TestDoExhausted(t *testing.T) {
+	ctx := context.Background()
+	errFlaky := errors.New("flaky")
+	calls := 0
+	err := Do(ctx, Backoff{Count: 3, Initial: time.Microsecond}, func(context.Context) error {
+		calls++
+		return errFlaky
+	})
+	if got, want := calls, 3; got != want {
+		t.Errorf("calls = %d, want %d", got, want)
+	}
+	if !errors.Is(err, errFlaky) {
+		t.Errorf("Do() error = %v, want %v", err, errFlaky)
+	}
+	if got, want := len(err.(interface{ Unwrap() []error }).Unwrap()), 3; got != want {
+		t.Errorf("len(Do() error.Unwrap()) = %d, want %d", got, want)
+	}
+}
+
+func TestDoPermanent(t *testing.T) {
+	ctx := context.Background()
+	errBad := errors.New("bad request")
+	errTemp := errors.New("temporary")
+
+	tests := []struct {
+		name      string
+		policy    Backoff
+		retryable func(error) bool
+		err       error
+		wantCalls int
+	}{
+		{
+			name:      "Permanent",
+			policy:    Backoff{Count: 5, Initial: time.Microsecond},
+			err:       fmt.Errorf("wrapped: %w", Permanent(errBad)),
+			wantCalls: 1,
+		},
+		{
+			name:      "Retryable",
+			policy:    Backoff{Count: 5, Initial: time.Microsecond},
+			retryable: func(err error) bool { return errors.Is(err, errTemp) },
+			err:       errBad,
+			wantCalls: 1,
+		},
+		{
+			name:      "Retryable allows",
+			policy:    Backoff{Count: 5, Initial: time.Microsecond},
+			retryable: func(err error) bool { return errors.Is(err, errTemp) },
+			err:       errTemp,
+			wantCalls: 5,
+		},
+	}
+	for _, test := range tests {
+		calls := 0
+		var opts []DoOption
+		if test.retryable != nil {
+			opts = append(opts, WithRetryable(test.retryable))
+		}
+		err := Do(ctx, test.policy, func(context.Context) error {
+			calls++
+			return test.err
+		}, opts...)
+		if calls != test.wantCalls {
+			t.Errorf("%s: calls = %d, want %d", test.name, calls, test.wantCalls)
+		}
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: Do() error = %v, want %v", test.name, err, test.err)
+		}
+	}
+}
+
+func TestDoRetryAfter(t *testing.T) {
+	ctx := context.Background()
+	var delays []time.Duration
+	policy := Backoff{
+		Count:   3,
+		Initial: time.Microsecond,
+		Jitter:  NoJitter,
+	}
+	Do(ctx, policy, func(context.Context) error {
+		return RetryAfter(errors.New("slow down"), 5*time.Millisecond)
+	}, WithOnRetry(func(_ int, _ error, d time.Duration) {
+		delays = append(delays, d)
+	}))
+	if len(delays) != 2 {
+		t.Fatalf("len(delays) = %d, want 2", len(delays))
+	}
+	for _, d := range delays {
+		if d < 5*time.Millisecond {
+			t.Errorf("delay = %v, want at least %v", d, 5*time.Millisecond)
+		}
+	}
+}
+
+func TestDoContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := Do(ctx, Backoff{Initial: time.Hour}, func(context.Context) error {
+		return errors.New("nope")
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Do() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
@@ -3,7 +3,6 @@ package exp
 import (
 	"context"
 	"iter"
-	"math/rand"
 	"time"
 )
 
@@ -27,35 +26,10 @@ import (
 // flavour Retry, Retry measures how long `yield` takes and subtracts it from
 // the next wait. Because `yield` can take arbitrarily long, it can therefore be
 // called again immediately.
+//
+// Retry(ctx, count, initial, grow) is equivalent to
+// Backoff{Count: count, Initial: initial, Grow: grow}.Retry(ctx), except that
+// at least one time is always yielded.
 func Retry(ctx context.Context, count int, initial time.Duration, grow float64) iter.Seq[time.Time] {
-	return func(yield func(time.Time) bool) {
-		if err := ctx.Err(); err != nil {
-			return
-		}
-
-		t := time.Now()
-		if !yield(t) {
-			return
-		}
-		yieldDur := time.Since(t)
-
-		df := float64(initial)
-		for n := 1; n < count; n++ {
-			d := time.Duration(rand.Int63n(int64(df))) - yieldDur
-			timer := time.NewTimer(d)
-			select {
-			case t := <-timer.C:
-				if !yield(t) {
-					return
-				}
-				yieldDur = time.Since(t)
-
-			case <-ctx.Done():
-				timer.Stop()
-				return
-			}
-
-			df *= grow
-		}
-	}
+	return Backoff{Count: max(count, 1), Initial: initial, Grow: grow}.Retry(ctx)
 }
@@ -0,0 +1,162 @@
+package exp
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"io"
+)
+
+// ScanOption configures how input is read and split into tokens by Lines,
+// LinesInFile, MustForEachLineIn, and NewScanner.
+type ScanOption func(*scanConfig)
+
+type scanConfig struct {
+	maxTokenSize int
+	split        bufio.SplitFunc
+	decompress   bool
+}
+
+// WithMaxTokenSize sets the maximum size of a token (e.g. the longest line).
+// The default is bufio.MaxScanTokenSize (64 KiB).
+func WithMaxTokenSize(n int) ScanOption {
+	return func(c *scanConfig) { c.maxTokenSize = n }
+}
+
+// WithSplit sets the function used to split input into tokens. The default
+// is bufio.ScanLines. See also ScanParagraphs, ScanNUL, and ScanFixed.
+func WithSplit(split bufio.SplitFunc) ScanOption {
+	return func(c *scanConfig) { c.split = split }
+}
+
+// WithDecompress transparently decompresses gzip- or bzip2-compressed input
+// (see Decompress).
+func WithDecompress() ScanOption {
+	return func(c *scanConfig) { c.decompress = true }
+}
+
+// NewScanner returns a bufio.Scanner reading from r configured with the
+// options. An error is only possible when using WithDecompress.
+func NewScanner(r io.Reader, opts ...ScanOption) (*bufio.Scanner, error) {
+	var c scanConfig
+	for _, o := range opts {
+		o(&c)
+	}
+	if c.decompress {
+		dr, err := Decompress(r)
+		if err != nil {
+			return nil, err
+		}
+		r = dr
+	}
+	sc := bufio.NewScanner(r)
+	if c.maxTokenSize > 0 {
+		sc.Buffer(make([]byte, 0, min(c.maxTokenSize, 4096)), c.maxTokenSize)
+	}
+	if c.split != nil {
+		sc.Split(c.split)
+	}
+	return sc, nil
+}
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+
+	// bzip2 streams begin with "BZh", a block size digit, and then either a
+	// block header or an end-of-stream marker.
+	bzip2BlockMagic = []byte{0x31, 0x41, 0x59, 0x26, 0x53, 0x59}
+	bzip2EOSMagic   = []byte{0x17, 0x72, 0x45, 0x38, 0x50, 0x90}
+)
+
+// Decompress inspects the first few bytes of r. If they indicate gzip or bzip2
+// compressed data, it returns a reader that decompresses r. Otherwise it
+// returns a reader that reads r unchanged.
+func Decompress(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(10)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		return gzip.NewReader(br)
+
+	case len(magic) == 10 && bytes.HasPrefix(magic, []byte("BZh")) &&
+		'1' <= magic[3] && magic[3] <= '9' &&
+		(bytes.Equal(magic[4:], bzip2BlockMagic) || bytes.Equal(magic[4:], bzip2EOSMagic)):
+		return bzip2.NewReader(br), nil
+	}
+	return br, nil
+}
+
+// ScanParagraphs is a bufio.SplitFunc that returns each paragraph of text,
+// where paragraphs are separated by one or more blank lines. The lines within
+// a paragraph are joined with "\n", and the trailing newline is removed.
+// Carriage returns at the ends of lines are left in place, except for the
+// last line in each paragraph.
+func ScanParagraphs(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	// Skip leading blank lines.
+	start := 0
+	for start < len(data) && (data[start] == '\n' || data[start] == '\r') {
+		start++
+	}
+
+	for i := start; i < len(data); {
+		j := bytes.IndexByte(data[i:], '\n')
+		if j < 0 {
+			break
+		}
+		end := i + j // position of the '\n' ending this line
+		next := end + 1
+		if next < len(data) && data[next] == '\r' {
+			next++
+		}
+		if next < len(data) && data[next] == '\n' {
+			return next + 1, bytes.TrimSuffix(data[start:end], []byte{'\r'}), nil
+		}
+		i = end + 1
+	}
+
+	if !atEOF {
+		// Request more data (but skip the leading blank lines already seen).
+		return start, nil, nil
+	}
+	if start == len(data) {
+		return len(data), nil, nil
+	}
+	return len(data), bytes.TrimRight(data[start:], "\r\n"), nil
+}
+
+// ScanNUL is a bufio.SplitFunc that returns each NUL-delimited record,
+// with the NUL removed. As with bufio.ScanLines, the last record need not be
+// terminated.
+func ScanNUL(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexByte(data, 0); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+// ScanFixed returns a bufio.SplitFunc that returns records of exactly n bytes.
+// The last record may be shorter, if the input length is not a multiple of n.
+func ScanFixed(n int) bufio.SplitFunc {
+	if n <= 0 {
+		panic("ScanFixed: record size must be positive")
+	}
+	return func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		if len(data) >= n {
+			return n, data[:n], nil
+		}
+		if atEOF && len(data) > 0 {
+			return len(data), data, nil
+		}
+		return 0, nil, nil
+	}
+}
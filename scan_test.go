@@ -0,0 +1,81 @@
+package exp
+
+import (
+	"bytes"
+	"compress/gzip"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// collect gathers all the tokens from Lines, failing the test on error.
+func collect(t *testing.T, input string, opts ...ScanOption) []string {
+	t.Helper()
+	var got []string
+	for tok, err := range Lines(strings.NewReader(input), opts...) {
+		if err != nil {
+			t.Fatalf("Lines() error = %v", err)
+		}
+		got = append(got, tok)
+	}
+	return got
+}
+
+func TestScanParagraphs(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{input: "", want: nil},
+		{input: "\n\n", want: nil},
+		{input: "a\nb\n\nc\n", want: []string{"a\nb", "c"}},
+		{input: "\n\na\n\n\n\nb", want: []string{"a", "b"}},
+		{input: "a\r\nb\r\n\r\nc\r\n", want: []string{"a\r\nb", "c"}},
+	}
+	for _, test := range tests {
+		got := collect(t, test.input, WithSplit(ScanParagraphs))
+		if diff := cmp.Diff(got, test.want); diff != "" {
+			t.Errorf("ScanParagraphs(%q) diff (-got +want):\n%s", test.input, diff)
+		}
+	}
+}
+
+func TestScanNULAndFixed(t *testing.T) {
+	if got, want := collect(t, "a\x00bc\x00\x00d", WithSplit(ScanNUL)), []string{"a", "bc", "", "d"}; !slices.Equal(got, want) {
+		t.Errorf("ScanNUL = %q, want %q", got, want)
+	}
+	if got, want := collect(t, "abcdefg", WithSplit(ScanFixed(3))), []string{"abc", "def", "g"}; !slices.Equal(got, want) {
+		t.Errorf("ScanFixed(3) = %q, want %q", got, want)
+	}
+}
+
+func TestWithMaxTokenSize(t *testing.T) {
+	long := strings.Repeat("x", 200_000)
+	got := collect(t, "short\n"+long+"\n", WithMaxTokenSize(1<<20))
+	if len(got) != 2 || got[1] != long {
+		t.Errorf("Lines(long line, WithMaxTokenSize) returned %d lines, want 2 with a long second line", len(got))
+	}
+}
+
+func TestWithDecompress(t *testing.T) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write([]byte("hello\nworld\n"))
+	zw.Close()
+
+	want := []string{"hello", "world"}
+	if got := collect(t, buf.String(), WithDecompress()); !slices.Equal(got, want) {
+		t.Errorf("Lines(gzip, WithDecompress) = %q, want %q", got, want)
+	}
+	// bzip2.compress(b"hello\nworld\n") in Python.
+	bz := "\x42\x5a\x68\x39\x31\x41\x59\x26\x53\x59\x6b\x5f\xb1\xdd\x00\x00\x02\x41\x80\x00\x10\x06\x44\x90\x80\x20\x00\x31\x0c\x08\x21\xa3\x69\x08\x07\x23\xae\x87\x8b\xb9\x22\x9c\x28\x48\x35\xaf\xd8\xee\x80"
+	if got := collect(t, bz, WithDecompress()); !slices.Equal(got, want) {
+		t.Errorf("Lines(bzip2, WithDecompress) = %q, want %q", got, want)
+	}
+	// Uncompressed input passes through unchanged.
+	if got := collect(t, "hello\nworld\n", WithDecompress()); !slices.Equal(got, want) {
+		t.Errorf("Lines(plain, WithDecompress) = %q, want %q", got, want)
+	}
+}
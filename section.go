@@ -0,0 +1,174 @@
+/*
+   Copyright 2026 Josh Deprez
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+	   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package exp
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"strings"
+
+	"drjosh.dev/exp/grid"
+	"drjosh.dev/exp/parse"
+)
+
+// Section is a block of consecutive non-blank lines of input. Sections are
+// separated by one or more blank lines (lines containing only whitespace).
+type Section struct {
+	Line  int      // 1-based line number of the first line of the section
+	Lines []string // the lines in the section
+}
+
+// Text returns the lines of the section joined with "\n".
+func (s Section) Text() string { return strings.Join(s.Lines, "\n") }
+
+// SectionDecoder decodes a section of input, typically into a variable
+// captured by the decoder. Any func(Section) error can be used as a custom
+// decoder.
+type SectionDecoder func(Section) error
+
+// MustReadSections reads the entire file into memory, splits it into
+// sections, and decodes them (see ReadSections).
+// If an error is encountered, it calls log.Fatal.
+// This is a helper intended for very simple programs (e.g. Advent of Code)
+// and is not recommended for production code, particularly because the
+// logged message may be somewhat unhelpful. Use ReadSections for a version
+// that returns errors.
+func MustReadSections(path string, decs ...SectionDecoder) []Section {
+	secs, err := ReadFile(path, func(r io.Reader) ([]Section, error) {
+		return ReadSections(r, decs...)
+	})
+	if err != nil {
+		log.Fatalf("MustReadSections: %v", err)
+	}
+	return secs
+}
+
+// ReadSections reads all of r into memory and splits it into sections.
+// If any decoders are given, there must be exactly one per section, and each
+// section is decoded by the corresponding decoder. For example:
+//
+//	var (
+//		g     grid.Dense[byte]
+//		moves []string
+//	)
+//	_, err := ReadSections(r, GridSection(&g), LinesSection(&moves))
+func ReadSections(r io.Reader, decs ...SectionDecoder) ([]Section, error) {
+	lines, err := ReadLines(r)
+	if err != nil {
+		return nil, err
+	}
+	var secs []Section
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if i == 0 || strings.TrimSpace(lines[i-1]) == "" {
+			secs = append(secs, Section{Line: i + 1})
+		}
+		last := &secs[len(secs)-1]
+		last.Lines = append(last.Lines, line)
+	}
+	if len(decs) == 0 {
+		return secs, nil
+	}
+	if len(decs) != len(secs) {
+		return secs, fmt.Errorf("found %d sections, want %d", len(secs), len(decs))
+	}
+	for i, dec := range decs {
+		if err := dec(secs[i]); err != nil {
+			return secs, fmt.Errorf("section %d: %w", i+1, err)
+		}
+	}
+	return secs, nil
+}
+
+// LinesSection decodes a section by storing its lines in *dst.
+func LinesSection(dst *[]string) SectionDecoder {
+	return func(s Section) error {
+		*dst = s.Lines
+		return nil
+	}
+}
+
+// GridSection decodes a section as a dense byte grid.
+func GridSection(dst *grid.Dense[byte]) SectionDecoder {
+	return func(s Section) error {
+		*dst = grid.BytesFromStrings(s.Lines)
+		return nil
+	}
+}
+
+// IntsSection decodes a section by extracting all the integers in it (see
+// parse.AllInts), regardless of how they are separated.
+func IntsSection(dst *[]int) SectionDecoder {
+	return func(s Section) error {
+		ints, err := parse.AllInts(s.Text())
+		if err != nil {
+			return err
+		}
+		*dst = ints
+		return nil
+	}
+}
+
+// IntLinesSection decodes a section by extracting all the integers in each
+// line (see parse.AllInts). Errors are reported as a *LineError.
+func IntLinesSection(dst *[][]int) SectionDecoder {
+	return ParseLinesSection(dst, parse.AllInts)
+}
+
+// KeyValueSection decodes a section of lines of the form "key<sep>value" into
+// a map. Keys and values are trimmed of leading and trailing whitespace.
+// Errors (a missing separator, or a duplicate key) are reported as a
+// *LineError.
+func KeyValueSection(dst *map[string]string, sep string) SectionDecoder {
+	return func(s Section) error {
+		m := make(map[string]string, len(s.Lines))
+		for i, line := range s.Lines {
+			k, v, ok := strings.Cut(line, sep)
+			if !ok {
+				return &LineError{Line: s.Line + i, Err: fmt.Errorf("%q not found in %q", sep, line)}
+			}
+			k = strings.TrimSpace(k)
+			if _, dup := m[k]; dup {
+				return &LineError{Line: s.Line + i, Err: fmt.Errorf("duplicate key %q", k)}
+			}
+			m[k] = strings.TrimSpace(v)
+		}
+		*dst = m
+		return nil
+	}
+}
+
+// ParseLinesSection decodes a section by parsing each line with f (for
+// example, parse.Ints or parse.Struct[T]). Errors are reported as a
+// *LineError.
+func ParseLinesSection[T any](dst *[]T, f func(string) (T, error)) SectionDecoder {
+	return func(s Section) error {
+		out := make([]T, len(s.Lines))
+		for i, line := range s.Lines {
+			t, err := f(line)
+			if err != nil {
+				return &LineError{Line: s.Line + i, Err: err}
+			}
+			out[i] = t
+		}
+		*dst = out
+		return nil
+	}
+}
@@ -0,0 +1,105 @@
+package exp
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"drjosh.dev/exp/grid"
+	"drjosh.dev/exp/parse"
+	"github.com/google/go-cmp/cmp"
+)
+
+const sectionsInput = "\n#.#\n.#.\n\n\n  \nwidth: 3\n height :2\n\n75,47,61\n97,13\n\n1 -2 3\n4\n"
+
+func TestReadSections(t *testing.T) {
+	var (
+		g       grid.Dense[byte]
+		kv      map[string]string
+		updates [][]int
+		total   int
+	)
+	secs, err := ReadSections(strings.NewReader(sectionsInput),
+		GridSection(&g),
+		KeyValueSection(&kv, ":"),
+		IntLinesSection(&updates),
+		func(s Section) error {
+			var ints []int
+			if err := IntsSection(&ints)(s); err != nil {
+				return err
+			}
+			for _, n := range ints {
+				total += n
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		t.Fatalf("ReadSections() error = %v", err)
+	}
+
+	wantSecs := []Section{
+		{Line: 2, Lines: []string{"#.#", ".#."}},
+		{Line: 7, Lines: []string{"width: 3", " height :2"}},
+		{Line: 10, Lines: []string{"75,47,61", "97,13"}},
+		{Line: 13, Lines: []string{"1 -2 3", "4"}},
+	}
+	if diff := cmp.Diff(secs, wantSecs); diff != "" {
+		t.Errorf("ReadSections() diff (-got +want):\n%s", diff)
+	}
+	if diff := cmp.Diff(g, grid.Dense[byte]{[]byte("#.#"), []byte(".#.")}); diff != "" {
+		t.Errorf("GridSection diff (-got +want):\n%s", diff)
+	}
+	if diff := cmp.Diff(kv, map[string]string{"width": "3", "height": "2"}); diff != "" {
+		t.Errorf("KeyValueSection diff (-got +want):\n%s", diff)
+	}
+	if diff := cmp.Diff(updates, [][]int{{75, 47, 61}, {97, 13}}); diff != "" {
+		t.Errorf("IntLinesSection diff (-got +want):\n%s", diff)
+	}
+	if got, want := total, 6; got != want {
+		t.Errorf("custom decoder total = %d, want %d", got, want)
+	}
+}
+
+func TestReadSectionsErrors(t *testing.T) {
+	if _, err := ReadSections(strings.NewReader(sectionsInput), LinesSection(new([]string))); err == nil {
+		t.Errorf("ReadSections(1 decoder) error = nil, want error for 4 sections")
+	}
+
+	var lines []string
+	var kv map[string]string
+	_, err := ReadSections(strings.NewReader("a\nb\n\nx=1\ny\n"),
+		LinesSection(&lines),
+		KeyValueSection(&kv, "="),
+	)
+	var le *LineError
+	if !errors.As(err, &le) {
+		t.Fatalf("ReadSections() error = %v, want *LineError", err)
+	}
+	if got, want := le.Line, 5; got != want {
+		t.Errorf("LineError.Line = %d, want %d", got, want)
+	}
+	if !strings.HasPrefix(err.Error(), "section 2: line 5: ") {
+		t.Errorf("ReadSections() error = %q, want prefix %q", err, "section 2: line 5: ")
+	}
+
+	type move struct {
+		N    int `lit:"move "`
+		From int `lit:" from "`
+		To   int `lit:" to "`
+	}
+	var moves []move
+	_, err = ReadSections(strings.NewReader("move 1 from 2 to 3\nmove x from 2 to 3\n"),
+		ParseLinesSection(&moves, parse.Struct[move]),
+	)
+	if !errors.As(err, &le) {
+		t.Fatalf("ReadSections() error = %v, want *LineError", err)
+	}
+	if got, want := le.Line, 2; got != want {
+		t.Errorf("LineError.Line = %d, want %d", got, want)
+	}
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("ReadSections() error = %v, want %v", err, strconv.ErrSyntax)
+	}
+}
@@ -79,13 +79,29 @@ func SliceSink[T any](ctx context.Context, in <-chan T) ([]T, error) {
 
 // TextFileSource reads lines from the file one at a time, and sends them into
 // the channel. When reading is complete the channel is closed.
-func TextFileSource(ctx context.Context, path string, out chan<- string) error {
+// If maxTokenSize > 0, it sets the maximum line length (the default is
+// bufio.MaxScanTokenSize). If split is not nil, it is used to split the file
+// into "lines" instead of bufio.ScanLines. For other ways of reading (such as
+// decompressing the file), set up a bufio.Scanner and use ScannerSource.
+func TextFileSource(ctx context.Context, path string, out chan<- string, maxTokenSize int, split bufio.SplitFunc) error {
 	f, err := os.Open(path)
 	if err != nil {
 		return fmt.Errorf("opening file: %w", err)
 	}
 	defer f.Close()
 	sc := bufio.NewScanner(f)
+	if maxTokenSize > 0 {
+		sc.Buffer(make([]byte, 0, min(maxTokenSize, 4096)), maxTokenSize)
+	}
+	if split != nil {
+		sc.Split(split)
+	}
+	return ScannerSource(ctx, sc, out)
+}
+
+// ScannerSource sends the text of each token from the scanner into the
+// channel. When the scanner is exhausted the channel is closed.
+func ScannerSource(ctx context.Context, sc *bufio.Scanner, out chan<- string) error {
 	for sc.Scan() {
 		select {
 		case out <- sc.Text():
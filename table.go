@@ -0,0 +1,225 @@
+/*
+   Copyright 2026 Josh Deprez
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+	   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package exp
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"reflect"
+	"strings"
+
+	"drjosh.dev/exp/grid"
+	"drjosh.dev/exp/parse"
+)
+
+// TableError records an error that occurred while converting a particular
+// cell of a table.
+type TableError struct {
+	Line   int    // 1-based line number
+	Column int    // 1-based column number
+	Header string // the header of the column
+	Err    error
+}
+
+func (e *TableError) Error() string {
+	return fmt.Sprintf("line %d, column %d (%s): %v", e.Line, e.Column, e.Header, e.Err)
+}
+
+func (e *TableError) Unwrap() error { return e.Err }
+
+// MustReadTable reads the file as a table (see ReadTable).
+// If an error is encountered, it calls log.Fatal.
+// This is a helper intended for very simple programs (e.g. Advent of Code)
+// and is not recommended for production code, particularly because the
+// logged message may be somewhat unhelpful. Use ReadTable for a version that
+// returns errors.
+func MustReadTable[T any](path string, comma rune) []T {
+	rows, err := ReadFile(path, func(r io.Reader) ([]T, error) {
+		return ReadTable[T](r, comma)
+	})
+	if err != nil {
+		log.Fatalf("MustReadTable: %v", err)
+	}
+	return rows
+}
+
+// MustReadTableGrid reads the file as a table of values of a single type (see
+// ReadTableGrid).
+// If an error is encountered, it calls log.Fatal.
+// This is a helper intended for very simple programs (e.g. Advent of Code)
+// and is not recommended for production code, particularly because the
+// logged message may be somewhat unhelpful. Use ReadTableGrid for a version
+// that returns errors.
+func MustReadTableGrid[T any](path string, comma rune) (header []string, g grid.Dense[T]) {
+	type table struct {
+		header []string
+		g      grid.Dense[T]
+	}
+	t, err := ReadFile(path, func(r io.Reader) (table, error) {
+		h, g, err := ReadTableGrid[T](r, comma)
+		return table{h, g}, err
+	})
+	if err != nil {
+		log.Fatalf("MustReadTableGrid: %v", err)
+	}
+	return t.header, t.g
+}
+
+// ReadTable reads a delimited table (for example, CSV with comma ',' or TSV
+// with comma '\t') from r, and converts each row after the header row into a
+// value of struct type T. Quoting follows encoding/csv, and leading and
+// trailing spaces are trimmed from every cell. Every row must have the same
+// number of cells as the header row.
+//
+// The header row is the schema: each column is stored in the field of T
+// with a matching `table:"header"` tag, or otherwise, the exported field
+// whose name matches the header (ignoring case). Columns with no matching
+// field are ignored, as are fields tagged `table:"-"`. It is an error for a
+// field with a table tag to have no matching column. Cells are converted as
+// described for parse.Value, except that empty cells leave pointers nil.
+//
+// Conversion errors are reported as a *TableError.
+func ReadTable[T any](r io.Reader, comma rune) ([]T, error) {
+	typ := reflect.TypeFor[T]()
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("ReadTable: want struct type, got %v", typ)
+	}
+	cr, header, err := newTableReader(r, comma)
+	if err != nil || header == nil {
+		return nil, err
+	}
+	fields, err := tableFields(typ, header)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []T
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		var t T
+		v := reflect.ValueOf(&t).Elem()
+		for i, cell := range rec {
+			if fields[i] == nil {
+				continue
+			}
+			if err := setCell(v.FieldByIndex(fields[i]), cell); err != nil {
+				return nil, tableError(cr, header, i, err)
+			}
+		}
+		rows = append(rows, t)
+	}
+}
+
+// ReadTableGrid reads a delimited table from r, as for ReadTable, but converts
+// every cell after the header row into a value of type T. It returns the
+// header row and a grid with one row per row of the table.
+func ReadTableGrid[T any](r io.Reader, comma rune) (header []string, g grid.Dense[T], err error) {
+	cr, header, err := newTableReader(r, comma)
+	if err != nil || header == nil {
+		return nil, nil, err
+	}
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			return header, g, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		row := make([]T, len(rec))
+		for i, cell := range rec {
+			if err := setCell(reflect.ValueOf(&row[i]).Elem(), cell); err != nil {
+				return nil, nil, tableError(cr, header, i, err)
+			}
+		}
+		g = append(g, row)
+	}
+}
+
+// newTableReader returns a csv.Reader for r, and the (trimmed) header row.
+// If r is empty, the header is nil.
+func newTableReader(r io.Reader, comma rune) (*csv.Reader, []string, error) {
+	cr := csv.NewReader(r)
+	cr.Comma = comma
+	cr.TrimLeadingSpace = true // allow quoted cells after ", "
+	cr.ReuseRecord = true
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	header = append([]string(nil), header...)
+	for i, h := range header {
+		header[i] = strings.TrimSpace(h)
+	}
+	return cr, header, nil
+}
+
+// tableFields returns the index of the field of t for each column in header
+// (nil for columns without a field).
+func tableFields(t reflect.Type, header []string) ([][]int, error) {
+	fields := make([][]int, len(header))
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name, tagged := sf.Tag.Lookup("table")
+		if name == "-" {
+			continue
+		}
+		found := false
+		for j, h := range header {
+			if (tagged && h == name) || (!tagged && strings.EqualFold(h, sf.Name)) {
+				if fields[j] != nil {
+					return nil, fmt.Errorf("column %d (%s) matches more than one field of %v", j+1, h, t)
+				}
+				fields[j] = sf.Index
+				found = true
+			}
+		}
+		if tagged && !found {
+			return nil, fmt.Errorf("no column for field %v.%s (%q)", t, sf.Name, name)
+		}
+	}
+	return fields, nil
+}
+
+// setCell converts the cell into v.
+func setCell(v reflect.Value, cell string) error {
+	cell = strings.TrimSpace(cell)
+	if cell == "" && v.Kind() == reflect.Pointer {
+		return nil
+	}
+	return parse.Value(cell, v.Addr().Interface())
+}
+
+// tableError returns a *TableError for column i of the most recent record.
+func tableError(cr *csv.Reader, header []string, i int, err error) error {
+	line, _ := cr.FieldPos(i)
+	return &TableError{Line: line, Column: i + 1, Header: header[i], Err: err}
+}
@@ -0,0 +1,103 @@
+package exp
+
+import (
+	"errors"
+	"net/netip"
+	"strconv"
+	"strings"
+	"testing"
+
+	"drjosh.dev/exp/grid"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReadTable(t *testing.T) {
+	type host struct {
+		Name    string
+		Addr    netip.Addr `table:"ip address"`
+		Port    uint16
+		Weight  float64
+		Enabled *bool
+		Notes   string `table:"-"`
+	}
+	const input = "name, ip address, port,enabled,weight,comment\n" +
+		"alpha, 10.0.0.1, 80, true, 0.5, first\n" +
+		"\"beta, gamma\",10.0.0.2,8080,,1,\n" +
+		"delta, \"10.0.0.3\", 81, false, 2, \"a, b\"\n"
+
+	got, err := ReadTable[host](strings.NewReader(input), ',')
+	if err != nil {
+		t.Fatalf("ReadTable() error = %v", err)
+	}
+	want := []host{
+		{Name: "alpha", Addr: netip.MustParseAddr("10.0.0.1"), Port: 80, Weight: 0.5, Enabled: Ptr(true)},
+		{Name: "beta, gamma", Addr: netip.MustParseAddr("10.0.0.2"), Port: 8080, Weight: 1},
+		{Name: "delta", Addr: netip.MustParseAddr("10.0.0.3"), Port: 81, Weight: 2, Enabled: Ptr(false)},
+	}
+	if diff := cmp.Diff(got, want, cmp.Comparer(func(a, b netip.Addr) bool { return a == b })); diff != "" {
+		t.Errorf("ReadTable() diff (-got +want):\n%s", diff)
+	}
+}
+
+func TestReadTableErrors(t *testing.T) {
+	type row struct {
+		A int
+		B int `table:"b"`
+	}
+	tests := []struct {
+		name, input, wantErr string
+	}{
+		{
+			name:    "bad cell",
+			input:   "a\tb\n1\t2\n3\tx\n",
+			wantErr: `line 3, column 2 (b): strconv.ParseInt: parsing "x": invalid syntax`,
+		},
+		{
+			name:    "missing column",
+			input:   "a\tc\n1\t2\n",
+			wantErr: `no column for field exp.row.B ("b")`,
+		},
+		{
+			name:    "short row",
+			input:   "a\tb\n1\n",
+			wantErr: "record on line 2: wrong number of fields",
+		},
+	}
+	for _, test := range tests {
+		_, err := ReadTable[row](strings.NewReader(test.input), '\t')
+		if err == nil || err.Error() != test.wantErr {
+			t.Errorf("%s: ReadTable() error = %v, want %q", test.name, err, test.wantErr)
+		}
+	}
+
+	_, err := ReadTable[row](strings.NewReader("a\tb\n1\t2\n3\tx\n"), '\t')
+	var te *TableError
+	if !errors.As(err, &te) {
+		t.Fatalf("ReadTable() error = %v, want *TableError", err)
+	}
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("ReadTable() error = %v, want %v", err, strconv.ErrSyntax)
+	}
+}
+
+func TestReadTableGrid(t *testing.T) {
+	header, g, err := ReadTableGrid[int](strings.NewReader("x, y, z\n1, 2, 3\n-4, 5, 6\n"), ',')
+	if err != nil {
+		t.Fatalf("ReadTableGrid() error = %v", err)
+	}
+	if diff := cmp.Diff(header, []string{"x", "y", "z"}); diff != "" {
+		t.Errorf("ReadTableGrid() header diff (-got +want):\n%s", diff)
+	}
+	if diff := cmp.Diff(g, grid.Dense[int]{{1, 2, 3}, {-4, 5, 6}}); diff != "" {
+		t.Errorf("ReadTableGrid() grid diff (-got +want):\n%s", diff)
+	}
+
+	_, _, err = ReadTableGrid[int](strings.NewReader("x,y\n1,2\n3,4.5\n"), ',')
+	var te *TableError
+	if !errors.As(err, &te) {
+		t.Fatalf("ReadTableGrid() error = %v, want *TableError", err)
+	}
+	if te.Line != 3 || te.Column != 2 || te.Header != "y" {
+		t.Errorf("TableError = %+v, want line 3, column 2, header y", te)
+	}
+}